@@ -0,0 +1,148 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+)
+
+// ErrTreeFull is returned when appending to an IncrementalTree with 2^depth leaves
+var ErrTreeFull = errors.New("the tree is full")
+
+// An IncrementalTree is an append-only Merkle tree of fixed depth, as used by
+// deposit contracts and anonymity sets. Leaves are leafSum(data), and missing
+// leaves are empty, as in a SparseTree: its root is the one of a SparseTree of
+// the same depth where IndexKey(i, depth) is set to the data of the i-th leaf.
+// Membership proofs can thus be produced by a SparseTree holding the leaves.
+//
+// Only the rightmost filled node of each level is stored, so that an append
+// costs depth hashes and the memory footprint is in O(depth). The last roots
+// are kept, so that proofs against a recent state can still be verified.
+type IncrementalTree struct {
+	hash     hash.Hash
+	depth    int
+	defaults [][]byte // defaults[i] is the root of an empty subtree of height i
+	filled   [][]byte // filled[i] is the last left node at height i
+	nbLeaves uint64
+
+	roots     [][]byte // circular buffer of the last roots
+	rootIndex int      // index of the current root in roots
+}
+
+// NewIncremental returns an empty IncrementalTree of the given depth, which
+// remembers its last historySize roots (at least 1).
+func NewIncremental(h hash.Hash, depth, historySize int) (*IncrementalTree, error) {
+	if depth < 1 || depth > 64 {
+		return nil, errors.New("depth must be between 1 and 64")
+	}
+	if historySize < 1 {
+		return nil, errors.New("the history must have a positive size")
+	}
+	defaults, err := defaultNodes(h, depth)
+	if err != nil {
+		return nil, err
+	}
+	t := &IncrementalTree{
+		hash:     h,
+		depth:    depth,
+		defaults: defaults,
+		filled:   make([][]byte, depth),
+		roots:    make([][]byte, historySize),
+	}
+	t.roots[0] = t.defaults[depth]
+	return t, nil
+}
+
+// Append adds leafSum(data) as the next leaf, and returns its index. If the hash
+// function rejects data, the tree is left unchanged.
+func (t *IncrementalTree) Append(data []byte) (uint64, error) {
+	if t.depth < 64 && t.nbLeaves == 1<<t.depth {
+		return 0, ErrTreeFull
+	}
+	index := t.nbLeaves
+	sum, err := leafSum(t.hash, data)
+	if err != nil {
+		return 0, err
+	}
+	filled := make([][]byte, t.depth)
+	for height := 0; height < t.depth; height++ {
+		if (index>>height)&1 == 0 {
+			filled[height] = sum
+			sum, err = nodeSum(t.hash, sum, t.defaults[height])
+		} else {
+			sum, err = nodeSum(t.hash, t.filled[height], sum)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	for height := range filled {
+		if filled[height] != nil {
+			t.filled[height] = filled[height]
+		}
+	}
+	t.nbLeaves++
+
+	t.rootIndex = (t.rootIndex + 1) % len(t.roots)
+	t.roots[t.rootIndex] = sum
+	return index, nil
+}
+
+// Root returns the current Merkle root
+func (t *IncrementalTree) Root() []byte {
+	return append([]byte{}, t.roots[t.rootIndex]...)
+}
+
+// NbLeaves returns the number of leaves appended to the tree
+func (t *IncrementalTree) NbLeaves() uint64 {
+	return t.nbLeaves
+}
+
+// IsKnownRoot returns true if root is one of the last roots of the tree
+func (t *IncrementalTree) IsKnownRoot(root []byte) bool {
+	for _, r := range t.roots {
+		if r != nil && bytes.Equal(r, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roots returns the last roots of the tree, from the most recent one
+func (t *IncrementalTree) Roots() [][]byte {
+	res := make([][]byte, 0, len(t.roots))
+	for i := 0; i < len(t.roots); i++ {
+		r := t.roots[(t.rootIndex-i+len(t.roots))%len(t.roots)]
+		if r == nil {
+			break
+		}
+		res = append(res, append([]byte{}, r...))
+	}
+	return res
+}
+
+// IndexKey returns the key of the i-th leaf of a SparseTree of the given depth,
+// i.e. i written on depth bits, most significant bit first.
+func IndexKey(i uint64, depth int) []byte {
+	res := make([]byte, (depth+7)/8)
+	i <<= (8*len(res) - depth) % 8
+	for j := len(res) - 1; j >= 0 && i != 0; j-- {
+		res[j] = byte(i)
+		i >>= 8
+	}
+	return res
+}
@@ -0,0 +1,410 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+var (
+	errIndexNotReached  = errors.New("index was not reached while creating proof")
+	errMalformedProof   = errors.New("malformed multiproof")
+	errMultiProofNotSet = errors.New("wrong usage: can't call ProveMulti on a tree if SetIndices wasn't called")
+)
+
+// A MultiProof proves that several leaves belong to a Merkle tree. It holds
+// the union of their authentication paths, without the nodes that can be
+// computed from the leaves: Hashes are the roots of the maximal subtrees
+// containing none of the proven leaves, in depth-first order.
+type MultiProof struct {
+	NumLeaves uint64
+	Indices   []uint64 // increasing
+	Leaves    [][]byte // Leaves[i] is the data of the leaf at Indices[i]
+	Hashes    [][]byte
+}
+
+// subTreeID identifies a complete subtree of a Tree
+type subTreeID struct {
+	start  uint64
+	height int
+}
+
+// multiProof holds the data recorded by a Tree while leaves are pushed
+type multiProof struct {
+	indices []uint64
+	leaves  [][]byte
+	sums    map[subTreeID][]byte // complete subtrees whose sibling contains a proven leaf
+}
+
+// SetIndices tells the Tree to create a proof for the leaves at the given
+// indices, which are sorted and deduplicated. SetIndices must be called on an
+// empty tree.
+func (t *Tree) SetIndices(indices []uint64) error {
+	if t.head != nil {
+		return errors.New("cannot call SetIndices on Tree if Tree has not been reset")
+	}
+	if len(indices) == 0 {
+		return errors.New("at least one index is needed")
+	}
+	sorted := append([]uint64{}, indices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := 1
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] != sorted[n-1] {
+			sorted[n] = sorted[i]
+			n++
+		}
+	}
+	t.multiProof = &multiProof{
+		indices: sorted[:n],
+		leaves:  make([][]byte, n),
+		sums:    make(map[subTreeID][]byte),
+	}
+	return nil
+}
+
+// containsIndex returns true if [start, end) contains one of the proven indices
+func (m *multiProof) containsIndex(start, end uint64) bool {
+	i := sort.Search(len(m.indices), func(i int) bool { return m.indices[i] >= start })
+	return i < len(m.indices) && m.indices[i] < end
+}
+
+// find returns the position of index in the proven indices, if present
+func (m *multiProof) find(index uint64) (int, bool) {
+	i := sort.Search(len(m.indices), func(i int) bool { return m.indices[i] >= index })
+	return i, i < len(m.indices) && m.indices[i] == index
+}
+
+// record stores the root of a complete subtree if it may be part of the proof
+func (m *multiProof) record(start uint64, height int, sum []byte) {
+	size := uint64(1) << height
+	if m.containsIndex(start, start+size) || !m.containsIndex(start^size, (start^size)+size) {
+		return
+	}
+	m.sums[subTreeID{start, height}] = sum
+}
+
+// ProveMulti creates a proof that the leaves at the indices established by
+// SetIndices are elements of the Merkle tree. ProveMulti does not modify the Tree.
+func (t *Tree) ProveMulti() (merkleRoot []byte, proof MultiProof, err error) {
+	if t.multiProof == nil {
+		return nil, MultiProof{}, errMultiProofNotSet
+	}
+	m := t.multiProof
+	if t.head == nil || m.indices[len(m.indices)-1] >= t.currentIndex {
+		return nil, MultiProof{}, errIndexNotReached
+	}
+
+	// the subtrees on the stack, from left to right
+	var stack []subTreeID
+	var sums [][]byte
+	for current := t.head; current != nil; current = current.next {
+		stack = append(stack, subTreeID{height: current.height})
+		sums = append(sums, current.sum)
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+		sums[i], sums[j] = sums[j], sums[i]
+	}
+	for i := 1; i < len(stack); i++ {
+		stack[i].start = stack[i-1].start + 1<<stack[i-1].height
+	}
+
+	proof = MultiProof{
+		NumLeaves: t.currentIndex,
+		Indices:   append([]uint64{}, m.indices...),
+		Leaves:    make([][]byte, len(m.leaves)),
+	}
+	for i := range m.leaves {
+		proof.Leaves[i] = append([]byte{}, m.leaves[i]...)
+	}
+
+	subTreeSum := func(start, end uint64) ([]byte, error) {
+		var res []byte
+		if size := end - start; size&(size-1) == 0 {
+			// complete subtree
+			var ok bool
+			if res, ok = m.sums[subTreeID{start, bits.TrailingZeros64(size)}]; ok {
+				proof.Hashes = append(proof.Hashes, res)
+				return res, nil
+			}
+		}
+		// subtrees of the stack, joined from the right
+		for i := len(stack) - 1; i >= 0 && stack[i].start >= start; i-- {
+			if stack[i].start+1<<stack[i].height > end {
+				continue
+			}
+			if res == nil {
+				res = sums[i]
+			} else {
+				var err error
+				if res, err = nodeSum(t.hash, sums[i], res); err != nil {
+					return nil, err
+				}
+			}
+			if stack[i].start == start {
+				proof.Hashes = append(proof.Hashes, res)
+				return res, nil
+			}
+		}
+		return nil, errors.New("subtree not recorded")
+	}
+
+	leaves := proof.Leaves
+	leafSumNext := func() ([]byte, error) {
+		s, err := leafSum(t.hash, leaves[0])
+		leaves = leaves[1:]
+		return s, err
+	}
+
+	merkleRoot, err = walkMultiProof(t.hash, proof.NumLeaves, proof.Indices, leafSumNext, subTreeSum)
+	return
+}
+
+// VerifyMultiProof returns true if proof shows that its leaves are elements of
+// the Merkle tree with the given root.
+func VerifyMultiProof(h hash.Hash, merkleRoot []byte, proof MultiProof) bool {
+	if merkleRoot == nil || len(proof.Indices) == 0 || len(proof.Indices) != len(proof.Leaves) {
+		return false
+	}
+	for i := range proof.Indices {
+		if (i > 0 && proof.Indices[i] <= proof.Indices[i-1]) || proof.Indices[i] >= proof.NumLeaves {
+			return false
+		}
+	}
+
+	leaves, hashes := proof.Leaves, proof.Hashes
+	leafSumNext := func() ([]byte, error) {
+		s, err := leafSum(h, leaves[0])
+		leaves = leaves[1:]
+		return s, err
+	}
+	subTreeSum := func(start, end uint64) ([]byte, error) {
+		if len(hashes) == 0 {
+			return nil, errMalformedProof
+		}
+		s := hashes[0]
+		hashes = hashes[1:]
+		return s, nil
+	}
+
+	root, err := walkMultiProof(h, proof.NumLeaves, proof.Indices, leafSumNext, subTreeSum)
+	return err == nil && len(hashes) == 0 && bytes.Equal(root, merkleRoot)
+}
+
+// walkMultiProof computes the root of a tree of numLeaves leaves, splitting it
+// as in RFC 6962: the left subtree is complete, of the largest possible size.
+// It recurses into the subtrees containing one of the (sorted) indices, calls
+// leafSum on the proven leaves and subTreeSum on the maximal subtrees containing
+// none of them, in depth-first order.
+func walkMultiProof(h hash.Hash, numLeaves uint64, indices []uint64, leafSum func() ([]byte, error), subTreeSum func(start, end uint64) ([]byte, error)) ([]byte, error) {
+	var walk func(start, end uint64) ([]byte, error)
+	walk = func(start, end uint64) ([]byte, error) {
+		if len(indices) == 0 || indices[0] >= end {
+			return subTreeSum(start, end)
+		}
+		if end-start == 1 {
+			indices = indices[1:]
+			return leafSum()
+		}
+		k := uint64(1) << (63 - bits.LeadingZeros64(end-start-1)) // largest power of 2 < end - start
+		left, err := walk(start, start+k)
+		if err != nil {
+			return nil, err
+		}
+		right, err := walk(start+k, end)
+		if err != nil {
+			return nil, err
+		}
+		return nodeSum(h, left, right)
+	}
+	if numLeaves == 0 {
+		return nil, errMalformedProof
+	}
+	return walk(0, numLeaves)
+}
+
+// BuildReaderMultiProof returns a proof that the leaves at the given indices
+// are in the merkle tree created by the data in the reader, along with its root.
+// All leaves will be 'segmentSize' bytes except the last leaf, which will not be
+// padded out if there are not enough bytes remaining in the reader.
+func BuildReaderMultiProof(r io.Reader, h hash.Hash, segmentSize int, indices []uint64) (root []byte, proof MultiProof, err error) {
+	tree := New(h)
+	if err = tree.SetIndices(indices); err != nil {
+		return
+	}
+	if err = tree.ReadAll(r, segmentSize); err != nil {
+		return
+	}
+	return tree.ProveMulti()
+}
+
+// WriteTo writes a compact binary encoding of the proof to w. Integers are
+// written as uvarints, indices as differences between successive indices, and
+// the hashes, which must have the same size, without length prefix:
+//
+//	NumLeaves ‖ len(Indices) ‖ Indices ‖ (len(Leaves[i]) ‖ Leaves[i])ᵢ ‖ len(Hashes) ‖ hashSize ‖ Hashes
+func (p *MultiProof) WriteTo(w io.Writer) (int64, error) {
+	if len(p.Indices) != len(p.Leaves) {
+		return 0, errMalformedProof
+	}
+	var buf bytes.Buffer
+	var tmp [binary.MaxVarintLen64]byte
+	writeUvarint := func(x uint64) {
+		buf.Write(tmp[:binary.PutUvarint(tmp[:], x)])
+	}
+
+	writeUvarint(p.NumLeaves)
+	writeUvarint(uint64(len(p.Indices)))
+	previous := uint64(0)
+	for i, index := range p.Indices {
+		if i > 0 && index <= previous {
+			return 0, errMalformedProof
+		}
+		writeUvarint(index - previous)
+		previous = index
+	}
+	for _, leaf := range p.Leaves {
+		writeUvarint(uint64(len(leaf)))
+		buf.Write(leaf)
+	}
+	writeUvarint(uint64(len(p.Hashes)))
+	hashSize := 0
+	if len(p.Hashes) != 0 {
+		hashSize = len(p.Hashes[0])
+	}
+	writeUvarint(uint64(hashSize))
+	for _, s := range p.Hashes {
+		if len(s) != hashSize {
+			return 0, errors.New("all the hashes of a multiproof must have the same size")
+		}
+		buf.Write(s)
+	}
+
+	return buf.WriteTo(w)
+}
+
+// maxMultiProofLen bounds the lengths read by MultiProof.ReadFrom
+const maxMultiProofLen = 1 << 26
+
+// ReadFrom reads a proof written by WriteTo. The slices are grown as their
+// elements are read, so that a malformed length prefix can't cause an
+// allocation larger than the input.
+func (p *MultiProof) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	readUvarint := func(max uint64) (uint64, error) {
+		x, err := binary.ReadUvarint(cr)
+		if err != nil {
+			return 0, err
+		}
+		if x > max {
+			return 0, errMalformedProof
+		}
+		return x, nil
+	}
+	readBytes := func(n uint64) ([]byte, error) {
+		res := bytes.NewBuffer([]byte{})
+		if _, err := io.CopyN(res, cr, int64(n)); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		return res.Bytes(), nil
+	}
+
+	var err error
+	if p.NumLeaves, err = readUvarint(^uint64(0)); err != nil {
+		return cr.n, err
+	}
+	// the indices are distinct leaves
+	maxIndices := uint64(maxMultiProofLen)
+	if p.NumLeaves < maxIndices {
+		maxIndices = p.NumLeaves
+	}
+	nbIndices, err := readUvarint(maxIndices)
+	if err != nil {
+		return cr.n, err
+	}
+	p.Indices = nil
+	previous := uint64(0)
+	for i := uint64(0); i < nbIndices; i++ {
+		delta, err := readUvarint(^uint64(0))
+		if err != nil {
+			return cr.n, err
+		}
+		if (i > 0 && delta == 0) || previous+delta < previous {
+			return cr.n, errMalformedProof
+		}
+		previous += delta
+		p.Indices = append(p.Indices, previous)
+	}
+	p.Leaves = nil
+	for i := uint64(0); i < nbIndices; i++ {
+		n, err := readUvarint(maxMultiProofLen)
+		if err != nil {
+			return cr.n, err
+		}
+		leaf, err := readBytes(n)
+		if err != nil {
+			return cr.n, err
+		}
+		p.Leaves = append(p.Leaves, leaf)
+	}
+	nbHashes, err := readUvarint(maxMultiProofLen)
+	if err != nil {
+		return cr.n, err
+	}
+	hashSize, err := readUvarint(1024)
+	if err != nil {
+		return cr.n, err
+	}
+	p.Hashes = nil
+	for i := uint64(0); i < nbHashes; i++ {
+		s, err := readBytes(hashSize)
+		if err != nil {
+			return cr.n, err
+		}
+		p.Hashes = append(p.Hashes, s)
+	}
+	return cr.n, nil
+}
+
+// countingReader counts the bytes read from r. It reads bytes one at a time,
+// so as not to consume more than the proof.
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(c, c.buf[:]); err != nil {
+		return 0, err
+	}
+	return c.buf[0], nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+func TestMultiProof(t *testing.T) {
+	t.Parallel()
+
+	h := sha256.New()
+	for _, numLeaves := range []uint64{1, 2, 3, 7, 8, 11, 64, 100, 1000} {
+		for _, nbIndices := range []int{1, 2, 5, 40} {
+			indices := make([]uint64, nbIndices)
+			for i := range indices {
+				indices[i] = uint64(rand.Int63n(int64(numLeaves))) //#nosec G404 weak rng is fine here
+			}
+
+			data := make([]byte, 32*numLeaves)
+			rand.Read(data) //#nosec G404 weak rng is fine here
+
+			tree := New(h)
+			if err := tree.SetIndices(indices); err != nil {
+				t.Fatal(err)
+			}
+			if err := tree.ReadAll(bytes.NewReader(data), 32); err != nil {
+				t.Fatal(err)
+			}
+			root, proof, err := tree.ProveMulti()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(root, tree.Root()) {
+				t.Fatalf("n=%d: wrong root", numLeaves)
+			}
+			if !VerifyMultiProof(h, root, proof) {
+				t.Fatalf("n=%d, indices=%v: proof rejected", numLeaves, indices)
+			}
+
+			// the multiproof is never larger than the single proofs
+			singleProofsSize := 0
+			for _, index := range proof.Indices {
+				_, proofSet, _, err := BuildReaderProof(bytes.NewReader(data), h, 32, index)
+				if err != nil {
+					t.Fatal(err)
+				}
+				singleProofsSize += len(proofSet) - 1
+			}
+			if len(proof.Hashes) > singleProofsSize {
+				t.Fatal("multiproof larger than the single proofs")
+			}
+
+			readerRoot, readerProof, err := BuildReaderMultiProof(bytes.NewReader(data), h, 32, indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(readerRoot, root) || !VerifyMultiProof(h, readerRoot, readerProof) {
+				t.Fatal("reader proof rejected")
+			}
+
+			// serialization
+			var buf bytes.Buffer
+			written, err := proof.WriteTo(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf.WriteString("trailing data")
+			var decoded MultiProof
+			read, err := decoded.ReadFrom(&buf)
+			if err != nil || read != written {
+				t.Fatal("could not read the proof back", err)
+			}
+			if buf.String() != "trailing data" {
+				t.Fatal("ReadFrom must not consume more than the proof")
+			}
+			if !VerifyMultiProof(h, root, decoded) {
+				t.Fatal("decoded proof rejected")
+			}
+
+			// tampering
+			proof.Leaves[0] = []byte("wrong")
+			if VerifyMultiProof(h, root, proof) {
+				t.Fatal("proof with a wrong leaf accepted")
+			}
+			if len(decoded.Hashes) != 0 {
+				decoded.Hashes = decoded.Hashes[1:]
+				if VerifyMultiProof(h, root, decoded) {
+					t.Fatal("proof with a missing hash accepted")
+				}
+			}
+		}
+	}
+}
+
+func TestMultiProofWrongUsage(t *testing.T) {
+	t.Parallel()
+
+	tree := New(sha256.New())
+	if _, _, err := tree.ProveMulti(); err == nil {
+		t.Fatal("ProveMulti without SetIndices must fail")
+	}
+	if err := tree.SetIndices([]uint64{1, 5}); err != nil {
+		t.Fatal(err)
+	}
+	tree.Push([]byte("leaf"))
+	tree.Push([]byte("leaf"))
+	if _, _, err := tree.ProveMulti(); err == nil {
+		t.Fatal("unreached indices must fail")
+	}
+	if err := tree.PushSubTree(1, make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.PushSubTree(1, make([]byte, 32)); err == nil {
+		t.Fatal("cached subtree containing a proven leaf accepted")
+	}
+}
+
+func TestMultiProofReadTruncated(t *testing.T) {
+	// headers claiming huge lengths, with no data behind them
+	uvarints := func(values ...uint64) []byte {
+		var res []byte
+		for _, v := range values {
+			res = binary.AppendUvarint(res, v)
+		}
+		return res
+	}
+	for _, data := range [][]byte{
+		uvarints(1<<40, maxMultiProofLen),              // indices
+		uvarints(1<<40, 1, 0, maxMultiProofLen),        // leaf
+		uvarints(1<<40, 1, 0, 0, maxMultiProofLen, 32), // hashes
+		uvarints(4, 5), // more indices than leaves
+	} {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		before := stats.TotalAlloc
+
+		var decoded MultiProof
+		if _, err := decoded.ReadFrom(bytes.NewReader(data)); err == nil {
+			t.Fatal("truncated proof accepted")
+		}
+
+		runtime.ReadMemStats(&stats)
+		if allocated := stats.TotalAlloc - before; allocated > 1<<20 {
+			t.Fatalf("reading a truncated proof allocated %d bytes", allocated)
+		}
+	}
+}
+
+func TestMultiProofNonCanonicalInput(t *testing.T) {
+	t.Parallel()
+
+	// MiMC rejects inputs that are not canonical field elements
+	h := mimc.NewMiMC()
+	invalid := bytes.Repeat([]byte{0xff}, fr.Bytes)
+
+	tree := New(h)
+	if err := tree.SetIndices([]uint64{1, 4}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		tree.Push(randomElement())
+	}
+	root, proof, err := tree.ProveMulti()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyMultiProof(h, root, proof) {
+		t.Fatal("proof rejected")
+	}
+
+	tampered := proof
+	tampered.Leaves = [][]byte{proof.Leaves[0], invalid}
+	if VerifyMultiProof(h, root, tampered) {
+		t.Fatal("proof with an invalid leaf accepted")
+	}
+	for i := range proof.Hashes {
+		tampered = proof
+		tampered.Hashes = append([][]byte{}, proof.Hashes...)
+		tampered.Hashes[i] = invalid
+		if VerifyMultiProof(h, root, tampered) {
+			t.Fatal("proof with an invalid hash accepted")
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var (
+	ErrInvalidDepth = errors.New("depth must be between 1 and 256")
+	ErrInvalidKey   = errors.New("invalid key: must be ⌈depth/8⌉ bytes long, unused low bits set to zero")
+)
+
+// A SparseTree is a Merkle tree of fixed depth mapping keys to values. The leaf
+// at position key is leafSum(value) if key is set, and the empty leaf otherwise.
+// The empty leaf is h.Size() zero bytes, which is a valid (zero) field element
+// for field-native hashes such as MiMC.
+//
+// Only the nodes that differ from the default ones, i.e. the roots of empty
+// subtrees, are stored, so that the memory footprint is in O(depth·n).
+type SparseTree struct {
+	hash     hash.Hash
+	depth    int
+	defaults [][]byte              // defaults[i] is the root of an empty subtree of height i
+	nodes    map[sparseNode][]byte // non default nodes
+	values   map[string][]byte     // values of the set keys
+}
+
+// sparseNode identifies a node by its height and the prefix of the keys below it
+type sparseNode struct {
+	height int
+	prefix string
+}
+
+// SparseProof proves that a key is set to Value in a SparseTree, or that it is
+// not set if Value is nil.
+type SparseProof struct {
+	Value []byte
+	// Empty is a bitmap of the siblings that are roots of empty subtrees: bit i
+	// is set if the sibling at height i is default, in which case it is omitted
+	// from Siblings.
+	Empty []byte
+	// Siblings are the non default siblings, from the leaf level to the root
+	Siblings [][]byte
+}
+
+// NewSparse returns an empty SparseTree of the given depth, whose keys are
+// ⌈depth/8⌉ bytes long. h is used for all hashing operations.
+func NewSparse(h hash.Hash, depth int) (*SparseTree, error) {
+	if depth < 1 || depth > 256 {
+		return nil, ErrInvalidDepth
+	}
+	defaults, err := defaultNodes(h, depth)
+	if err != nil {
+		return nil, err
+	}
+	return &SparseTree{
+		hash:     h,
+		depth:    depth,
+		defaults: defaults,
+		nodes:    make(map[sparseNode][]byte),
+		values:   make(map[string][]byte),
+	}, nil
+}
+
+// defaultNodes returns the roots of empty subtrees of height 0 to depth
+func defaultNodes(h hash.Hash, depth int) ([][]byte, error) {
+	res := make([][]byte, depth+1)
+	res[0] = make([]byte, h.Size())
+	for i := 1; i <= depth; i++ {
+		var err error
+		if res[i], err = nodeSum(h, res[i-1], res[i-1]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Depth returns the depth of the tree
+func (t *SparseTree) Depth() int {
+	return t.depth
+}
+
+// Root returns the Merkle root of the tree
+func (t *SparseTree) Root() []byte {
+	return t.node(t.depth, "")
+}
+
+// Get returns the value of key, or nil if it is not set
+func (t *SparseTree) Get(key []byte) ([]byte, error) {
+	if err := checkKey(key, t.depth); err != nil {
+		return nil, err
+	}
+	return t.values[string(key)], nil
+}
+
+// Set sets key to value. A nil value removes the key from the tree.
+func (t *SparseTree) Set(key, value []byte) error {
+	return t.SetBatch([][]byte{key}, [][]byte{value})
+}
+
+// SetBatch sets keys[i] to values[i], with nil values removing keys. Nodes
+// shared by the paths of several keys are hashed once. If a key appears
+// more than once, its last value is kept. If the hash function rejects a
+// value, the tree is left unchanged.
+func (t *SparseTree) SetBatch(keys, values [][]byte) error {
+	if len(keys) != len(values) {
+		return errors.New("there must be as many keys as values")
+	}
+	for _, key := range keys {
+		if err := checkKey(key, t.depth); err != nil {
+			return err
+		}
+	}
+
+	// the new nodes are computed before any of them is stored, so that a hash
+	// error leaves the tree unchanged
+	updates := make(map[sparseNode][]byte)
+	node := func(height int, prefix string) []byte {
+		if n, ok := updates[sparseNode{height, prefix}]; ok {
+			if n == nil {
+				return t.defaults[height]
+			}
+			return n
+		}
+		return t.node(height, prefix)
+	}
+
+	// compute the leaves, and collect the prefixes of their parents
+	dirty := make(map[string]struct{}, len(keys))
+	for i, key := range keys {
+		var s []byte
+		if values[i] != nil {
+			var err error
+			if s, err = leafSum(t.hash, values[i]); err != nil {
+				return err
+			}
+		}
+		updates[sparseNode{0, string(key)}] = s
+		dirty[prefix(key, t.depth-1)] = struct{}{}
+	}
+
+	// recompute the parents, level by level
+	for height := 1; height <= t.depth; height++ {
+		parents := make(map[string]struct{}, len(dirty))
+		for p := range dirty {
+			left, right := children(p, t.depth-height)
+			s, err := nodeSum(t.hash, node(height-1, left), node(height-1, right))
+			if err != nil {
+				return err
+			}
+			updates[sparseNode{height, p}] = s
+			if height < t.depth {
+				parents[prefix([]byte(p), t.depth-height-1)] = struct{}{}
+			}
+		}
+		dirty = parents
+	}
+
+	for i, key := range keys {
+		if values[i] == nil {
+			delete(t.values, string(key))
+		} else {
+			t.values[string(key)] = append([]byte{}, values[i]...)
+		}
+	}
+	for id, n := range updates {
+		t.setNode(id, n)
+	}
+	return nil
+}
+
+// Prove returns a proof that key is set to its current value, or that it is
+// not set.
+func (t *SparseTree) Prove(key []byte) (SparseProof, error) {
+	if err := checkKey(key, t.depth); err != nil {
+		return SparseProof{}, err
+	}
+	var proof SparseProof
+	if v, ok := t.values[string(key)]; ok {
+		proof.Value = append([]byte{}, v...)
+	}
+	proof.Empty = make([]byte, (t.depth+7)/8)
+	for height := 0; height < t.depth; height++ {
+		sibling := prefix(key, t.depth-height)
+		sibling = flipBit(sibling, t.depth-height-1)
+		s := t.node(height, sibling)
+		if bytes.Equal(s, t.defaults[height]) {
+			proof.Empty[height/8] |= 1 << (height % 8)
+		} else {
+			proof.Siblings = append(proof.Siblings, s)
+		}
+	}
+	return proof, nil
+}
+
+// VerifySparseProof returns true if proof shows that key is set to proof.Value
+// (or not set, if proof.Value is nil) in the SparseTree of the given depth and root.
+// It returns false if the hash function rejects the value or a sibling.
+func VerifySparseProof(h hash.Hash, depth int, root, key []byte, proof SparseProof) bool {
+	if depth < 1 || depth > 256 || checkKey(key, depth) != nil || len(proof.Empty) != (depth+7)/8 {
+		return false
+	}
+	// the unused high bits of Empty must be zero, as in keys, so that a proof
+	// has a single encoding
+	if depth%8 != 0 && proof.Empty[len(proof.Empty)-1]&(0xff<<(depth%8)) != 0 {
+		return false
+	}
+	defaults, err := defaultNodes(h, depth)
+	if err != nil {
+		return false
+	}
+
+	sum := defaults[0]
+	if proof.Value != nil {
+		if sum, err = leafSum(h, proof.Value); err != nil {
+			return false
+		}
+	}
+	siblings := proof.Siblings
+	for height := 0; height < depth; height++ {
+		var sibling []byte
+		if proof.Empty[height/8]>>(height%8)&1 == 1 {
+			sibling = defaults[height]
+		} else {
+			if len(siblings) == 0 {
+				return false
+			}
+			sibling, siblings = siblings[0], siblings[1:]
+			// default siblings must be flagged in Empty
+			if bytes.Equal(sibling, defaults[height]) {
+				return false
+			}
+		}
+		if bit(key, depth-height-1) == 0 {
+			sum, err = nodeSum(h, sum, sibling)
+		} else {
+			sum, err = nodeSum(h, sibling, sum)
+		}
+		if err != nil {
+			return false
+		}
+	}
+	return len(siblings) == 0 && bytes.Equal(sum, root)
+}
+
+// node returns the node at the given height whose keys start with prefix
+func (t *SparseTree) node(height int, prefix string) []byte {
+	if n, ok := t.nodes[sparseNode{height, prefix}]; ok {
+		return n
+	}
+	return t.defaults[height]
+}
+
+// setNode stores n, unless it is the default node
+func (t *SparseTree) setNode(id sparseNode, n []byte) {
+	if n == nil || bytes.Equal(n, t.defaults[id.height]) {
+		delete(t.nodes, id)
+		return
+	}
+	t.nodes[id] = n
+}
+
+// checkKey returns an error if key is not a valid key for a tree of the given depth
+func checkKey(key []byte, depth int) error {
+	if len(key) != (depth+7)/8 {
+		return fmt.Errorf("%w: got %d bytes for depth %d", ErrInvalidKey, len(key), depth)
+	}
+	if depth%8 != 0 && key[len(key)-1]&(0xff>>(depth%8)) != 0 {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// prefix returns the first n bits of key, as a string of ⌈n/8⌉ bytes with the
+// unused low bits set to zero
+func prefix(key []byte, n int) string {
+	res := make([]byte, (n+7)/8)
+	copy(res, key)
+	if n%8 != 0 {
+		res[len(res)-1] &= 0xff << (8 - n%8)
+	}
+	return string(res)
+}
+
+// children returns the prefixes of length n+1 extending p, which has length n
+func children(p string, n int) (left, right string) {
+	res := make([]byte, n/8+1)
+	copy(res, p)
+	left = string(res)
+	res[n/8] |= 0x80 >> (n % 8)
+	right = string(res)
+	return
+}
+
+// flipBit returns p with its i-th bit flipped, counting from the most significant bit
+func flipBit(p string, i int) string {
+	res := []byte(p)
+	res[i/8] ^= 0x80 >> (i % 8)
+	return string(res)
+}
+
+// bit returns the i-th bit of key, counting from the most significant bit
+func bit(key []byte, i int) byte {
+	return key[i/8] >> (7 - i%8) & 1
+}
@@ -0,0 +1,300 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// randomElement returns the encoding of a random field element, which MiMC accepts as input
+func randomElement() []byte {
+	var e fr.Element
+	e.SetRandom()
+	b := e.Bytes()
+	return b[:]
+}
+
+func TestSparseTree(t *testing.T) {
+	t.Parallel()
+
+	const depth = 13
+	h := mimc.NewMiMC()
+	tree, err := NewSparse(h, depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyRoot := tree.Root()
+
+	keys := make([][]byte, 20)
+	values := make([][]byte, len(keys))
+	for i := range keys {
+		keys[i] = IndexKey(uint64(rand.Intn(1<<depth-1)), depth) //#nosec G404 weak rng is fine here
+		values[i] = randomElement()
+	}
+	if err = tree.SetBatch(keys, values); err != nil {
+		t.Fatal(err)
+	}
+
+	// the batch update must match sequential updates
+	sequential, _ := NewSparse(h, depth)
+	for i := range keys {
+		if err = sequential.Set(keys[i], values[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := tree.Root()
+	if !bytes.Equal(root, sequential.Root()) {
+		t.Fatal("batch and sequential updates differ")
+	}
+
+	// membership
+	for _, key := range keys {
+		proof, err := tree.Prove(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, _ := tree.Get(key)
+		if !bytes.Equal(proof.Value, v) || !VerifySparseProof(h, depth, root, key, proof) {
+			t.Fatal("membership proof rejected")
+		}
+		proof.Value = randomElement()
+		if VerifySparseProof(h, depth, root, key, proof) {
+			t.Fatal("membership proof with a wrong value accepted")
+		}
+	}
+
+	// non-membership
+	absent := IndexKey(1<<depth-1, depth)
+	proof, err := tree.Prove(absent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.Value != nil || !VerifySparseProof(h, depth, root, absent, proof) {
+		t.Fatal("non-membership proof rejected")
+	}
+	if VerifySparseProof(h, depth, root, keys[0], proof) {
+		t.Fatal("non-membership proof accepted for a set key")
+	}
+
+	// proofs have a single encoding
+	tampered := proof
+	tampered.Empty = append([]byte{}, proof.Empty...)
+	tampered.Empty[len(tampered.Empty)-1] |= 0x80
+	if VerifySparseProof(h, depth, root, absent, tampered) {
+		t.Fatal("proof with non-zero padding bits accepted")
+	}
+	for height := 0; height < depth; height++ {
+		if proof.Empty[height/8]>>(height%8)&1 == 0 {
+			continue
+		}
+		// send the default sibling explicitly
+		tampered.Empty = append([]byte{}, proof.Empty...)
+		tampered.Empty[height/8] &^= 1 << (height % 8)
+		tampered.Siblings = append([][]byte{}, proof.Siblings[:countSiblings(proof.Empty, height)]...)
+		tampered.Siblings = append(tampered.Siblings, tree.defaults[height])
+		tampered.Siblings = append(tampered.Siblings, proof.Siblings[countSiblings(proof.Empty, height):]...)
+		if VerifySparseProof(h, depth, root, absent, tampered) {
+			t.Fatal("proof with an explicit default sibling accepted")
+		}
+		break
+	}
+
+	// removing all the keys yields the empty tree
+	if err = tree.SetBatch(keys, make([][]byte, len(keys))); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tree.Root(), emptyRoot) || len(tree.nodes) != 0 {
+		t.Fatal("removing all the keys must yield the empty tree")
+	}
+
+	if err = tree.Set([]byte{0xff, 0xff}, randomElement()); err == nil {
+		t.Fatal("key with unused bits set accepted")
+	}
+}
+
+// countSiblings returns the number of non default siblings below height
+func countSiblings(empty []byte, height int) int {
+	n := 0
+	for i := 0; i < height; i++ {
+		if empty[i/8]>>(i%8)&1 == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestIncrementalTree(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		hash func() hash.Hash
+		data func() []byte
+	}{
+		{func() hash.Hash { return mimc.NewMiMC() }, randomElement},
+		{sha256.New, func() []byte { return []byte("data") }},
+	} {
+		const depth = 10
+		hFunc := tc.hash()
+
+		tree, err := NewIncremental(hFunc, depth, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sparse, _ := NewSparse(hFunc, depth)
+		if !bytes.Equal(tree.Root(), sparse.Root()) {
+			t.Fatal("empty roots differ")
+		}
+
+		var roots [][]byte
+		for i := 0; i < 37; i++ {
+			data := tc.data()
+			index, err := tree.Append(data)
+			if err != nil || index != uint64(i) {
+				t.Fatal("wrong index")
+			}
+			if err = sparse.Set(IndexKey(index, depth), data); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(tree.Root(), sparse.Root()) {
+				t.Fatal("incremental and sparse roots differ")
+			}
+			roots = append(roots, tree.Root())
+		}
+
+		// root history
+		for i, root := range roots {
+			if tree.IsKnownRoot(root) != (i >= len(roots)-4) {
+				t.Fatal("wrong root history")
+			}
+		}
+		history := tree.Roots()
+		if len(history) != 4 || !bytes.Equal(history[0], tree.Root()) || !bytes.Equal(history[3], roots[len(roots)-4]) {
+			t.Fatal("wrong root history")
+		}
+
+		// proofs from the sparse tree
+		proof, _ := sparse.Prove(IndexKey(17, depth))
+		if !VerifySparseProof(hFunc, depth, tree.Root(), IndexKey(17, depth), proof) {
+			t.Fatal("membership proof rejected")
+		}
+	}
+
+	tree, _ := NewIncremental(sha256.New(), 2, 1)
+	for i := 0; i < 4; i++ {
+		if _, err := tree.Append([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tree.Append(nil); err != ErrTreeFull {
+		t.Fatal("expected a full tree")
+	}
+}
+
+func TestNonCanonicalInput(t *testing.T) {
+	t.Parallel()
+
+	// MiMC rejects inputs that are not canonical field elements
+	const depth = 8
+	h := mimc.NewMiMC()
+	invalid := bytes.Repeat([]byte{0xff}, fr.Bytes)
+
+	tree, err := NewSparse(h, depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := IndexKey(3, depth)
+	if err = tree.Set(key, randomElement()); err != nil {
+		t.Fatal(err)
+	}
+	root := tree.Root()
+	if err = tree.SetBatch([][]byte{IndexKey(1, depth), key}, [][]byte{randomElement(), invalid}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !bytes.Equal(tree.Root(), root) || len(tree.values) != 1 {
+		t.Fatal("a rejected batch must not change the tree")
+	}
+
+	proof, err := tree.Prove(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := proof
+	tampered.Value = invalid
+	if VerifySparseProof(h, depth, root, key, tampered) {
+		t.Fatal("proof with an invalid value accepted")
+	}
+	tampered = proof
+	tampered.Empty = make([]byte, len(proof.Empty))
+	tampered.Siblings = make([][]byte, depth)
+	for i := range tampered.Siblings {
+		tampered.Siblings[i] = invalid
+	}
+	if VerifySparseProof(h, depth, root, key, tampered) {
+		t.Fatal("proof with invalid siblings accepted")
+	}
+
+	incremental, err := NewIncremental(h, depth, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = incremental.Append(randomElement()); err != nil {
+		t.Fatal(err)
+	}
+	root = incremental.Root()
+	if _, err = incremental.Append(invalid); err == nil {
+		t.Fatal("expected an error")
+	}
+	if incremental.NbLeaves() != 1 || !bytes.Equal(incremental.Root(), root) {
+		t.Fatal("a rejected leaf must not change the tree")
+	}
+
+	// VerifyProof
+	data := [][]byte{randomElement(), randomElement(), randomElement()}
+	tr := New(h)
+	if err = tr.SetIndex(1); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range data {
+		tr.Push(d)
+	}
+	root, proofSet, proofIndex, numLeaves := tr.Prove()
+	if !VerifyProof(h, root, proofSet, proofIndex, numLeaves) {
+		t.Fatal("proof rejected")
+	}
+	for i := range proofSet {
+		tampered := append([][]byte{}, proofSet...)
+		tampered[i] = invalid
+		if VerifyProof(h, root, tampered, proofIndex, numLeaves) {
+			t.Fatal("proof with an invalid element accepted")
+		}
+	}
+}
+
+func TestIndexKey(t *testing.T) {
+	t.Parallel()
+
+	if !bytes.Equal(IndexKey(5, 3), []byte{0xa0}) ||
+		!bytes.Equal(IndexKey(0x1ff, 13), []byte{0x0f, 0xf8}) ||
+		!bytes.Equal(IndexKey(0x1234, 16), []byte{0x12, 0x34}) {
+		t.Fatal("wrong key")
+	}
+}
@@ -54,6 +54,10 @@ type Tree struct {
 	proofSet     [][]byte
 	proofTree    bool
 
+	// multiProof records the leaves and subtrees needed to prove the leaves
+	// at the indices set by SetIndices.
+	multiProof *multiProof
+
 	// The cachedTree flag indicates that the tree is cached, meaning that
 	// different code is used in 'Push' for creating a new head subtree. Adding
 	// this flag is somewhat gross, but eliminates needing to duplicate the
@@ -71,25 +75,25 @@ type subTree struct {
 }
 
 // sum returns the hash of the input data using the specified algorithm.
-func sum(h hash.Hash, data ...[]byte) []byte {
+// Field-native hashes such as MiMC return an error on inputs that are not
+// canonical field elements, which verifiers may receive from untrusted proofs.
+func sum(h hash.Hash, data ...[]byte) ([]byte, error) {
 
 	h.Reset()
 
 	for _, d := range data {
-		// the Hash interface specifies that Write never returns an error
-		_, err := h.Write(d)
-		if err != nil {
-			panic(err)
+		if _, err := h.Write(d); err != nil {
+			return nil, err
 		}
 	}
-	return h.Sum(nil)
+	return h.Sum(nil), nil
 }
 
 // leafSum returns the hash created from data inserted to form a leaf. Leaf
 // sums are calculated using:
 //
 //	Hash(0x00 || data)
-func leafSum(h hash.Hash, data []byte) []byte {
+func leafSum(h hash.Hash, data []byte) ([]byte, error) {
 
 	//return sum(h, leafHashPrefix, data)
 	return sum(h, data)
@@ -99,12 +103,13 @@ func leafSum(h hash.Hash, data []byte) []byte {
 // a parent node. Node sums are calculated using:
 //
 //	Hash(0x01 || left sibling sum || right sibling sum)
-func nodeSum(h hash.Hash, a, b []byte) []byte {
+func nodeSum(h hash.Hash, a, b []byte) ([]byte, error) {
 	//return sum(h, nodeHashPrefix, a, b)
 	return sum(h, a, b)
 }
 
-// joinSubTrees combines two equal sized subTrees into a larger subTree.
+// joinSubTrees combines two equal sized subTrees into a larger subTree. It
+// panics if the hash function rejects the sums, as Push does.
 func joinSubTrees(h hash.Hash, a, b *subTree) *subTree {
 	// if DEBUG {
 	// 	if b.next != a {
@@ -115,10 +120,14 @@ func joinSubTrees(h hash.Hash, a, b *subTree) *subTree {
 	// 	}
 	// }
 
+	s, err := nodeSum(h, a.sum, b.sum)
+	if err != nil {
+		panic(err)
+	}
 	return &subTree{
 		next:   a.next,
 		height: a.height + 1,
-		sum:    nodeSum(h, a.sum, b.sum),
+		sum:    s,
 	}
 }
 
@@ -202,13 +211,19 @@ func (t *Tree) Prove() (merkleRoot []byte, proofSet [][]byte, proofIndex uint64,
 // tree does not remember all elements that are added, instead only keeping the
 // log(n) elements that are necessary to build the Merkle root and keeping the
 // log(n) elements necessary to build a proof that a piece of data is in the
-// Merkle tree.
+// Merkle tree. Push panics if the hash function rejects data, e.g. if data is
+// not a canonical field element for MiMC.
 func (t *Tree) Push(data []byte) {
 	// The first element of a proof is the data at the proof index. If this
 	// data is being inserted at the proof index, it is added to the proof set.
 	if t.currentIndex == t.proofIndex {
 		t.proofSet = append(t.proofSet, data)
 	}
+	if t.multiProof != nil {
+		if i, ok := t.multiProof.find(t.currentIndex); ok {
+			t.multiProof.leaves[i] = data
+		}
+	}
 
 	// Hash the data to create a subtree of height 0. The sum of the new node
 	// is going to be the data for cached trees, and is going to be the result
@@ -221,7 +236,14 @@ func (t *Tree) Push(data []byte) {
 	if t.cachedTree {
 		t.head.sum = data
 	} else {
-		t.head.sum = leafSum(t.hash, data)
+		s, err := leafSum(t.hash, data)
+		if err != nil {
+			panic(err)
+		}
+		t.head.sum = s
+	}
+	if t.multiProof != nil {
+		t.multiProof.record(t.currentIndex, 0, t.head.sum)
 	}
 
 	// Join subTrees if possible.
@@ -259,6 +281,12 @@ func (t *Tree) PushSubTree(height int, sum []byte) error {
 		(t.currentIndex < t.proofIndex && t.proofIndex < newIndex)) {
 		return errors.New("the cached tree shouldn't contain the element to prove")
 	}
+	if t.multiProof != nil {
+		if t.multiProof.containsIndex(t.currentIndex, newIndex) {
+			return errors.New("the cached tree shouldn't contain the elements to prove")
+		}
+		t.multiProof.record(t.currentIndex, height, sum)
+	}
 
 	// We can only add the cached tree if its depth is <= the depth of the
 	// current subtree.
@@ -361,5 +389,9 @@ func (t *Tree) joinAllSubTrees() {
 		// Join the two subTrees into one subTree with a greater height. Then
 		// compare the new subTree to the next subTree.
 		t.head = joinSubTrees(t.hash, t.head.next, t.head)
+		if t.multiProof != nil {
+			size := uint64(1) << t.head.height
+			t.multiProof.record(t.currentIndex/size*size, t.head.height, t.head.sum)
+		}
 	}
 }
@@ -26,8 +26,9 @@ import (
 
 // VerifyProof takes a Merkle root, a proofSet, and a proofIndex and returns
 // true if the first element of the proof set is a leaf of data in the Merkle
-// root. False is returned if the proof set or Merkle root is nil, and if
-// 'numLeaves' equals 0.
+// root. False is returned if the proof set or Merkle root is nil, if
+// 'numLeaves' equals 0, and if the hash function rejects an element of the
+// proof set.
 func VerifyProof(h hash.Hash, merkleRoot []byte, proofSet [][]byte, proofIndex uint64, numLeaves uint64) bool {
 	// Return false for nonsense input. A switch statement is used so that the
 	// cover tool will reveal if a case is not covered by the test suite. This
@@ -76,7 +77,10 @@ func VerifyProof(h hash.Hash, merkleRoot []byte, proofSet [][]byte, proofIndex u
 	if len(proofSet) <= height {
 		return false
 	}
-	sum := leafSum(h, proofSet[height])
+	sum, err := leafSum(h, proofSet[height])
+	if err != nil {
+		return false
+	}
 
 	height++
 
@@ -108,9 +112,12 @@ func VerifyProof(h hash.Hash, merkleRoot []byte, proofSet [][]byte, proofIndex u
 			return false
 		}
 		if proofIndex-subTreeStartIndex < 1<<uint(height-1) {
-			sum = nodeSum(h, sum, proofSet[height])
+			sum, err = nodeSum(h, sum, proofSet[height])
 		} else {
-			sum = nodeSum(h, proofSet[height], sum)
+			sum, err = nodeSum(h, proofSet[height], sum)
+		}
+		if err != nil {
+			return false
 		}
 		height++
 	}
@@ -122,13 +129,17 @@ func VerifyProof(h hash.Hash, merkleRoot []byte, proofSet [][]byte, proofIndex u
 		if len(proofSet) <= height {
 			return false
 		}
-		sum = nodeSum(h, sum, proofSet[height])
+		if sum, err = nodeSum(h, sum, proofSet[height]); err != nil {
+			return false
+		}
 		height++
 	}
 
 	// All remaining elements in the proof set will belong to a left sibling.
 	for height < len(proofSet) {
-		sum = nodeSum(h, proofSet[height], sum)
+		if sum, err = nodeSum(h, proofSet[height], sum); err != nil {
+			return false
+		}
 		height++
 	}
 
@@ -0,0 +1,331 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mmr provides an append-only Merkle Mountain Range, with inclusion
+// and consistency proofs following RFC 9162.
+//
+// A Merkle Mountain Range of n leaves is a list of perfect binary trees, the
+// peaks, whose sizes are the powers of 2 in the binary decomposition of n, in
+// decreasing order. Its root is obtained by bagging the peaks from the right:
+//
+//	root = H(peak₀ ‖ H(peak₁ ‖ ... H(peakₖ₋₂ ‖ peakₖ₋₁)))
+//
+// which is the root of the RFC 6962 Merkle tree of the same leaves. As in
+// accumulator/merkletree, leaves are H(data) and nodes H(left ‖ right), without
+// the RFC 6962 domain separation prefixes, so that field-native hashes such as
+// MiMC (see hash.Hash) can be used: their inputs must then be field elements.
+package mmr
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"math/bits"
+)
+
+var (
+	ErrInvalidSize  = errors.New("invalid tree size")
+	ErrInvalidIndex = errors.New("leaf index out of range")
+)
+
+// MMR is an append-only Merkle Mountain Range. It stores all its nodes, so that
+// proofs against any of its past sizes can be produced.
+type MMR struct {
+	hash hash.Hash
+	// nodes[h][i] is the root of the perfect subtree of height h over the leaves
+	// [i·2ʰ, (i+1)·2ʰ)
+	nodes [][][]byte
+}
+
+// New returns an empty MMR using h for all hashing operations
+func New(h hash.Hash) *MMR {
+	return &MMR{hash: h, nodes: [][][]byte{nil}}
+}
+
+// sum returns H(data[0] ‖ ... ‖ data[k-1]). Field-native hashes reject
+// inputs that are not canonical field elements, which may come from an
+// untrusted proof, so the error must be handled and not panicked on.
+func sum(h hash.Hash, data ...[]byte) ([]byte, error) {
+	h.Reset()
+	for _, d := range data {
+		if _, err := h.Write(d); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// Append adds a leaf with the given data, and returns its index. If the data is
+// rejected by the hash function, the MMR is left unchanged.
+func (m *MMR) Append(data []byte) (uint64, error) {
+	index := m.Size()
+
+	// newNodes[h] is the node appended at height h
+	s, err := sum(m.hash, data)
+	if err != nil {
+		return 0, err
+	}
+	newNodes := [][]byte{s}
+	for height, n := 0, index+1; n%2 == 0; height, n = height+1, n/2 {
+		level := m.nodes[height]
+		if s, err = sum(m.hash, level[len(level)-1], s); err != nil {
+			return 0, err
+		}
+		newNodes = append(newNodes, s)
+	}
+
+	for height, s := range newNodes {
+		if height == len(m.nodes) {
+			m.nodes = append(m.nodes, nil)
+		}
+		m.nodes[height] = append(m.nodes[height], s)
+	}
+	return index, nil
+}
+
+// Size returns the number of leaves
+func (m *MMR) Size() uint64 {
+	return uint64(len(m.nodes[0]))
+}
+
+// Peaks returns the roots of the perfect subtrees of the MMR, from the left
+func (m *MMR) Peaks() [][]byte {
+	return m.peaks(m.Size())
+}
+
+func (m *MMR) peaks(size uint64) [][]byte {
+	res := make([][]byte, 0, bits.OnesCount64(size))
+	start := uint64(0)
+	for height := 63; height >= 0; height-- {
+		if size>>height&1 == 1 {
+			res = append(res, m.nodes[height][start>>height])
+			start += 1 << height
+		}
+	}
+	return res
+}
+
+// BagPeaks returns the root of a MMR with the given peaks, from the left.
+func BagPeaks(h hash.Hash, peaks [][]byte) ([]byte, error) {
+	if len(peaks) == 0 {
+		return nil, nil
+	}
+	root := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		var err error
+		if root, err = sum(h, peaks[i], root); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// Root returns the root of the MMR, or nil if it is empty
+func (m *MMR) Root() ([]byte, error) {
+	return BagPeaks(m.hash, m.Peaks())
+}
+
+// RootAt returns the root the MMR had when it had size leaves
+func (m *MMR) RootAt(size uint64) ([]byte, error) {
+	if size == 0 || size > m.Size() {
+		return nil, ErrInvalidSize
+	}
+	return BagPeaks(m.hash, m.peaks(size))
+}
+
+// subtreeRoot returns the root of the RFC 6962 tree over the leaves [start, end)
+func (m *MMR) subtreeRoot(start, end uint64) ([]byte, error) {
+	n := end - start
+	if n&(n-1) == 0 && start%n == 0 {
+		height := bits.TrailingZeros64(n)
+		return m.nodes[height][start>>height], nil
+	}
+	k := largestPowerOfTwoBelow(n)
+	left, err := m.subtreeRoot(start, start+k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := m.subtreeRoot(start+k, end)
+	if err != nil {
+		return nil, err
+	}
+	return sum(m.hash, left, right)
+}
+
+// largestPowerOfTwoBelow returns the largest power of 2 smaller than n > 1
+func largestPowerOfTwoBelow(n uint64) uint64 {
+	return 1 << (63 - bits.LeadingZeros64(n-1))
+}
+
+// ProveInclusion returns the audit path of the leaf at index in the MMR of the
+// given size, which may be smaller than the current one.
+func (m *MMR) ProveInclusion(index, size uint64) ([][]byte, error) {
+	if size == 0 || size > m.Size() {
+		return nil, ErrInvalidSize
+	}
+	if index >= size {
+		return nil, ErrInvalidIndex
+	}
+	// RFC 6962, PATH(m, D[n])
+	var path [][]byte
+	start, end := uint64(0), size
+	for end-start > 1 {
+		k := largestPowerOfTwoBelow(end - start)
+		var node []byte
+		var err error
+		if index < start+k {
+			node, err = m.subtreeRoot(start+k, end)
+			end = start + k
+		} else {
+			node, err = m.subtreeRoot(start, start+k)
+			start += k
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, node)
+	}
+	// from the leaf to the root
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// VerifyInclusion returns true if proof shows that the leaf at index in the MMR
+// of the given size and root has the given data (RFC 9162, section 2.1.3.2).
+// It returns false if the hash function rejects the data or a node of the proof.
+func VerifyInclusion(h hash.Hash, root, data []byte, index, size uint64, proof [][]byte) bool {
+	if index >= size {
+		return false
+	}
+	fn, sn := index, size-1
+	r, err := sum(h, data)
+	if err != nil {
+		return false
+	}
+	for _, p := range proof {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			r, err = sum(h, p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r, err = sum(h, r, p)
+		}
+		if err != nil {
+			return false
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(r, root)
+}
+
+// ProveConsistency returns a proof that the MMR of size newSize extends the one
+// of size oldSize, both of which may be smaller than the current size.
+func (m *MMR) ProveConsistency(oldSize, newSize uint64) ([][]byte, error) {
+	if oldSize == 0 || oldSize > newSize || newSize > m.Size() {
+		return nil, ErrInvalidSize
+	}
+	// RFC 6962, SUBPROOF(m, D[n], true)
+	var proof [][]byte
+	start, end, complete := uint64(0), newSize, true
+	for {
+		n := end - start
+		if oldSize-start == n {
+			if !complete {
+				node, err := m.subtreeRoot(start, end)
+				if err != nil {
+					return nil, err
+				}
+				proof = append(proof, node)
+			}
+			break
+		}
+		k := largestPowerOfTwoBelow(n)
+		var node []byte
+		var err error
+		if oldSize-start <= k {
+			node, err = m.subtreeRoot(start+k, end)
+			end = start + k
+		} else {
+			node, err = m.subtreeRoot(start, start+k)
+			start += k
+			complete = false
+		}
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, node)
+	}
+	// the nodes are appended from the root, and must be read from the leaves
+	for i, j := 0, len(proof)-1; i < j; i, j = i+1, j-1 {
+		proof[i], proof[j] = proof[j], proof[i]
+	}
+	return proof, nil
+}
+
+// VerifyConsistency returns true if proof shows that the MMR of size newSize and
+// root newRoot extends the one of size oldSize and root oldRoot (RFC 9162,
+// section 2.1.4.2). It returns false if the hash function rejects a node of the
+// proof.
+func VerifyConsistency(h hash.Hash, oldRoot, newRoot []byte, oldSize, newSize uint64, proof [][]byte) bool {
+	if oldSize == 0 || oldSize > newSize {
+		return false
+	}
+	if oldSize == newSize {
+		return len(proof) == 0 && bytes.Equal(oldRoot, newRoot)
+	}
+	if oldSize&(oldSize-1) == 0 {
+		proof = append([][]byte{oldRoot}, proof...)
+	}
+	if len(proof) == 0 {
+		return false
+	}
+	fn, sn := oldSize-1, newSize-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+	fr, sr := proof[0], proof[0]
+	var err error
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			if fr, err = sum(h, c, fr); err != nil {
+				return false
+			}
+			sr, err = sum(h, c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr, err = sum(h, sr, c)
+		}
+		if err != nil {
+			return false
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(fr, oldRoot) && bytes.Equal(sr, newRoot)
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/bits"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	gchash "github.com/consensys/gnark-crypto/hash"
+)
+
+// leafData returns the data of the i-th leaf, a valid MiMC input
+func leafData(i int) []byte {
+	var e fr.Element
+	e.SetUint64(uint64(i) * 7919)
+	b := e.Bytes()
+	return b[:]
+}
+
+func TestMMR(t *testing.T) {
+	t.Parallel()
+
+	const n = 45
+	h := gchash.MIMC_BN254.New()
+	m := New(h)
+	tree := merkletree.New(gchash.MIMC_BN254.New())
+	roots := make([][]byte, n+1)
+	for i := 0; i < n; i++ {
+		index, err := m.Append(leafData(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index != uint64(i) {
+			t.Fatal("wrong index")
+		}
+		tree.Push(leafData(i))
+		if roots[i+1], err = m.Root(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(roots[i+1], tree.Root()) {
+			t.Fatalf("size %d: the root must match the RFC 6962 tree", i+1)
+		}
+		if len(m.Peaks()) != bits.OnesCount(uint(i+1)) {
+			t.Fatal("wrong number of peaks")
+		}
+	}
+
+	for size := uint64(1); size <= n; size++ {
+		root, err := m.RootAt(size)
+		if err != nil || !bytes.Equal(root, roots[size]) {
+			t.Fatal("wrong historical root")
+		}
+
+		// inclusion in historical roots
+		for index := uint64(0); index < size; index++ {
+			proof, err := m.ProveInclusion(index, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !VerifyInclusion(h, roots[size], leafData(int(index)), index, size, proof) {
+				t.Fatalf("size %d, index %d: inclusion proof rejected", size, index)
+			}
+			if VerifyInclusion(h, roots[size], leafData(int(index)+1), index, size, proof) {
+				t.Fatal("inclusion proof with wrong data accepted")
+			}
+			if size > 1 && VerifyInclusion(h, roots[size], leafData(int(index)), index^1, size, proof) {
+				t.Fatal("inclusion proof with wrong index accepted")
+			}
+		}
+
+		// consistency with all the later sizes
+		for newSize := size; newSize <= n; newSize++ {
+			proof, err := m.ProveConsistency(size, newSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !VerifyConsistency(h, roots[size], roots[newSize], size, newSize, proof) {
+				t.Fatalf("%d → %d: consistency proof rejected", size, newSize)
+			}
+			if size != newSize && VerifyConsistency(h, roots[newSize], roots[newSize], size, newSize, proof) {
+				t.Fatal("consistency proof with wrong old root accepted")
+			}
+		}
+	}
+
+	if _, err := m.ProveInclusion(3, n+1); err != ErrInvalidSize {
+		t.Fatal("expected an invalid size")
+	}
+	if _, err := m.ProveInclusion(n, n); err != ErrInvalidIndex {
+		t.Fatal("expected an invalid index")
+	}
+}
+
+func TestConsistencyRFC6962Shape(t *testing.T) {
+	t.Parallel()
+
+	// from RFC 6962, section 2.1.3: PROOF(3, D[7]) = [c, d, g, l]
+	h := sha256.New()
+	m := New(h)
+	for i := 0; i < 7; i++ {
+		if _, err := m.Append([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	proof, err := m.ProveConsistency(3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := m.nodes[0][2]
+	d := m.nodes[0][3]
+	g := m.nodes[1][0]
+	l, err := m.subtreeRoot(4, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]byte{c, d, g, l}
+	if len(proof) != len(expected) {
+		t.Fatal("wrong proof size")
+	}
+	for i := range proof {
+		if !bytes.Equal(proof[i], expected[i]) {
+			t.Fatal("wrong proof")
+		}
+	}
+}
+
+func TestNonCanonicalInput(t *testing.T) {
+	t.Parallel()
+
+	// MiMC rejects inputs that are not canonical field elements
+	h := gchash.MIMC_BN254.New()
+	invalid := bytes.Repeat([]byte{0xff}, fr.Bytes)
+
+	m := New(h)
+	for i := 0; i < 3; i++ {
+		if _, err := m.Append(leafData(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err := m.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Append(invalid); err == nil {
+		t.Fatal("expected an error")
+	}
+	if m.Size() != 3 {
+		t.Fatal("a rejected leaf must not change the MMR")
+	}
+	if r, err := m.Root(); err != nil || !bytes.Equal(r, root) {
+		t.Fatal("a rejected leaf must not change the root")
+	}
+
+	proof, err := m.ProveInclusion(0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyInclusion(h, root, invalid, 0, 3, proof) {
+		t.Fatal("inclusion of an invalid leaf accepted")
+	}
+	proof[0] = invalid
+	if VerifyInclusion(h, root, leafData(0), 0, 3, proof) {
+		t.Fatal("inclusion proof with an invalid node accepted")
+	}
+
+	proof, err = m.ProveConsistency(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRoot, err := m.RootAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range proof {
+		tampered := append([][]byte{}, proof...)
+		tampered[i] = invalid
+		if VerifyConsistency(h, oldRoot, root, 1, 3, tampered) {
+			t.Fatal("consistency proof with an invalid node accepted")
+		}
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	m := New(sha256.New())
+	data := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.Append(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
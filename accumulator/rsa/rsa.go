@@ -0,0 +1,531 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rsa provides a dynamic RSA-group set accumulator with constant-size
+// membership and non-membership witnesses (Camenisch and Lysyanskaya, CRYPTO 2002;
+// non-membership witnesses and their updates after Li, Li and Xue, ACNS 2007).
+//
+// Elements are mapped to primes with HashToPrime. The accumulator of a set X is
+// g^u mod N, where u = ∏_{x ∈ X} HashToPrime(x). The manager knows the
+// factorization of N, which makes deletions cheap. Anyone can verify witnesses
+// with the public key (N, g) and update them after additions and deletions.
+// Consecutive updates of the same kind are merged, so that a run of deletions
+// costs a single Bézout computation per witness.
+package rsa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrAlreadyMember       = errors.New("element already in the accumulator")
+	ErrNotMember           = errors.New("element not in the accumulator")
+	ErrVerifyMembership    = errors.New("can't verify membership witness")
+	ErrVerifyNonMembership = errors.New("can't verify non-membership witness")
+	ErrInvalidUpdate       = errors.New("invalid update")
+)
+
+// PrimeBits is the size of the primes returned by HashToPrime
+const PrimeBits = 256
+
+var one = big.NewInt(1)
+
+// PublicKey of an accumulator
+type PublicKey struct {
+	N *big.Int // RSA modulus
+	G *big.Int // quadratic residue modulo N
+}
+
+// Accumulator is a dynamic accumulator, held by its manager who knows the factorization of N.
+type Accumulator struct {
+	pk       PublicKey
+	phi      *big.Int // (p-1)(q-1)
+	value    *big.Int
+	elements map[string]*big.Int // element → prime representative
+}
+
+// Operation is the kind of an Update
+type Operation uint8
+
+const (
+	OpAdd Operation = iota
+	OpDelete
+)
+
+// Update describes the addition or deletion of an element. Updates are published
+// by the manager, so that holders of witnesses can update them.
+type Update struct {
+	Op       Operation
+	Prime    *big.Int // prime representative of the element
+	Value    *big.Int // value of the accumulator after the update
+	Previous *big.Int // value of the accumulator before the update
+}
+
+// MembershipWitness shows that Element is in the accumulated set: W^x = value,
+// where x = HashToPrime(Element).
+type MembershipWitness struct {
+	Element []byte
+	W       *big.Int
+	x       *big.Int
+}
+
+// NonMembershipWitness shows that Element is not in the accumulated set:
+// value^A·B^y = g, where y = HashToPrime(Element), that is A·u + b·y = 1 with B = g^b.
+type NonMembershipWitness struct {
+	Element []byte
+	A       *big.Int
+	B       *big.Int
+	y       *big.Int
+}
+
+// HashToPrime maps data to a prime of PrimeBits bits, by hashing it with an
+// increasing counter until the result, with its top bit set, is a probable prime.
+func HashToPrime(data []byte) *big.Int {
+	var counter [8]byte
+	res := new(big.Int)
+	for i := uint64(0); ; i++ {
+		binary.BigEndian.PutUint64(counter[:], i)
+		h := sha256.New()
+		h.Write(counter[:])
+		h.Write(data)
+		b := h.Sum(nil)
+		b[0] |= 0x80
+		b[len(b)-1] |= 1
+		res.SetBytes(b)
+		if res.ProbablyPrime(20) {
+			return res
+		}
+	}
+}
+
+// GenerateKey returns an accumulator of the empty set over a fresh modulus of
+// the given size.
+func GenerateKey(rand io.Reader, bits int) (*Accumulator, error) {
+	if bits < 64 {
+		return nil, errors.New("the modulus is too small")
+	}
+	for {
+		p, err := randPrime(rand, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := randPrime(rand, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) != 0 {
+			return New(p, q)
+		}
+	}
+}
+
+func randPrime(rand io.Reader, bits int) (*big.Int, error) {
+	// crypto/rand.Prime isn't used to let callers pass a deterministic source
+	b := make([]byte, (bits+7)/8)
+	p := new(big.Int)
+	for {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return nil, err
+		}
+		b[0] &= byte(0xff >> (8*len(b) - bits))
+		b[0] |= byte(0xc0 >> (8*len(b) - bits))
+		b[len(b)-1] |= 1
+		p.SetBytes(b)
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// New returns an accumulator of the empty set over the modulus N = p·q, where
+// p and q are distinct primes that must be kept secret.
+func New(p, q *big.Int) (*Accumulator, error) {
+	if p.Cmp(q) == 0 || !p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+		return nil, errors.New("p and q must be distinct primes")
+	}
+	acc := &Accumulator{elements: make(map[string]*big.Int)}
+	acc.pk.N = new(big.Int).Mul(p, q)
+	acc.phi = new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+
+	// g is the square of a nothing-up-my-sleeve number derived from N
+	h := sha256.Sum256(acc.pk.N.Bytes())
+	acc.pk.G = new(big.Int).SetBytes(h[:])
+	acc.pk.G.Exp(acc.pk.G, big.NewInt(2), acc.pk.N)
+	acc.value = new(big.Int).Set(acc.pk.G)
+	return acc, nil
+}
+
+// PublicKey returns the public key of the accumulator
+func (acc *Accumulator) PublicKey() PublicKey {
+	return acc.pk
+}
+
+// Value returns the current value of the accumulator
+func (acc *Accumulator) Value() *big.Int {
+	return new(big.Int).Set(acc.value)
+}
+
+// Contains returns true if element is in the accumulated set
+func (acc *Accumulator) Contains(element []byte) bool {
+	_, ok := acc.elements[string(element)]
+	return ok
+}
+
+// Add adds elements to the accumulated set, and returns the corresponding updates.
+// If one of the elements can't be added, the accumulator is left unchanged.
+func (acc *Accumulator) Add(elements ...[]byte) ([]Update, error) {
+	// validate the whole batch before changing the state
+	batch := make(map[string]struct{}, len(elements))
+	for _, element := range elements {
+		if _, ok := batch[string(element)]; ok || acc.Contains(element) {
+			return nil, ErrAlreadyMember
+		}
+		batch[string(element)] = struct{}{}
+	}
+
+	updates := make([]Update, len(elements))
+	value := acc.value
+	for i, element := range elements {
+		x := HashToPrime(element)
+		updates[i] = Update{Op: OpAdd, Prime: x, Previous: value}
+		value = new(big.Int).Exp(value, x, acc.pk.N)
+		updates[i].Value = value
+	}
+
+	acc.value = value
+	for i, element := range elements {
+		acc.elements[string(element)] = updates[i].Prime
+	}
+	return updates, nil
+}
+
+// Delete removes elements from the accumulated set, and returns the corresponding updates.
+// If one of the elements can't be removed, the accumulator is left unchanged.
+func (acc *Accumulator) Delete(elements ...[]byte) ([]Update, error) {
+	// validate the whole batch before changing the state
+	batch := make(map[string]struct{}, len(elements))
+	for _, element := range elements {
+		if _, ok := batch[string(element)]; ok || !acc.Contains(element) {
+			return nil, ErrNotMember
+		}
+		batch[string(element)] = struct{}{}
+	}
+
+	updates := make([]Update, len(elements))
+	value := acc.value
+	for i, element := range elements {
+		x := acc.elements[string(element)]
+		previous := value
+		var err error
+		if value, err = acc.root(value, x); err != nil {
+			return nil, err
+		}
+		updates[i] = Update{Op: OpDelete, Prime: x, Previous: previous, Value: value}
+	}
+
+	acc.value = value
+	for _, element := range elements {
+		delete(acc.elements, string(element))
+	}
+	return updates, nil
+}
+
+// root returns the x-th root of v modulo N
+func (acc *Accumulator) root(v, x *big.Int) (*big.Int, error) {
+	// x is a large random prime: it divides φ(N) with negligible probability
+	e := new(big.Int).ModInverse(x, acc.phi)
+	if e == nil {
+		return nil, errors.New("element prime divides φ(N)")
+	}
+	return e.Exp(v, e, acc.pk.N), nil
+}
+
+// MembershipWitness returns a witness that element is in the accumulated set
+func (acc *Accumulator) MembershipWitness(element []byte) (MembershipWitness, error) {
+	x, ok := acc.elements[string(element)]
+	if !ok {
+		return MembershipWitness{}, ErrNotMember
+	}
+	w, err := acc.root(acc.value, x)
+	if err != nil {
+		return MembershipWitness{}, err
+	}
+	return MembershipWitness{
+		Element: append([]byte{}, element...),
+		W:       w,
+		x:       x,
+	}, nil
+}
+
+// NonMembershipWitness returns a witness that element is not in the accumulated set
+func (acc *Accumulator) NonMembershipWitness(element []byte) (NonMembershipWitness, error) {
+	if acc.Contains(element) {
+		return NonMembershipWitness{}, ErrAlreadyMember
+	}
+	y := HashToPrime(element)
+
+	// u = ∏ x
+	u := big.NewInt(1)
+	for _, x := range acc.elements {
+		u.Mul(u, x)
+	}
+
+	// A·u + b·y = 1, with 0 ≤ A < y
+	a, b := bezout(u, y)
+	if a == nil {
+		// y divides u: a distinct element hashes to the same prime
+		return NonMembershipWitness{}, ErrAlreadyMember
+	}
+	bb := expSigned(acc.pk.G, b, acc.pk.N)
+	if bb == nil {
+		return NonMembershipWitness{}, errors.New("generator not invertible modulo N")
+	}
+	return NonMembershipWitness{
+		Element: append([]byte{}, element...),
+		A:       a,
+		B:       bb,
+		y:       y,
+	}, nil
+}
+
+// VerifyMembership checks that w shows that its element is in the set accumulated in value
+func (pk *PublicKey) VerifyMembership(value *big.Int, w MembershipWitness) error {
+	if w.W == nil {
+		return ErrVerifyMembership
+	}
+	x := HashToPrime(w.Element)
+	if new(big.Int).Exp(w.W, x, pk.N).Cmp(value) != 0 {
+		return ErrVerifyMembership
+	}
+	return nil
+}
+
+// VerifyNonMembership checks that w shows that its element is not in the set accumulated in value
+func (pk *PublicKey) VerifyNonMembership(value *big.Int, w NonMembershipWitness) error {
+	if w.A == nil || w.B == nil || w.A.Sign() < 0 {
+		return ErrVerifyNonMembership
+	}
+	y := HashToPrime(w.Element)
+	lhs := new(big.Int).Exp(value, w.A, pk.N)
+	lhs.Mul(lhs, new(big.Int).Exp(w.B, y, pk.N)).Mod(lhs, pk.N)
+	if lhs.Cmp(pk.G) != 0 {
+		return ErrVerifyNonMembership
+	}
+	return nil
+}
+
+// Update updates the witness after the given updates, in order, with the public
+// key only. The updates must follow each other, as returned by Add and Delete.
+// On error, the witness is left unchanged.
+//
+// After the addition of y, W' = W^y.
+// After the deletion of y ≠ x, W' = W^b·value'^a where a·x + b·y = 1 and value'
+// is the accumulator after the update.
+// These formulas hold for a product y of primes, so that runs of additions or
+// deletions are applied at once.
+func (w *MembershipWitness) Update(pk PublicKey, updates ...Update) error {
+	runs, err := mergeUpdates(updates)
+	if err != nil {
+		return err
+	}
+	return w.update(pk, runs)
+}
+
+// Update updates the witness after the given updates, in order, with the public
+// key only. The updates must follow each other, as returned by Add and Delete.
+// On error, the witness is left unchanged.
+//
+// After the addition of x, with a₀·x + r₀·y = 1 and A·a₀ = A' + k·y,
+// B' = B·value^(r₀·A)·value'^k, where value and value' are the accumulator
+// before and after the update.
+// After the deletion of x, with A·x = A' + k·y, B' = B·value'^k.
+// These formulas hold for a product x of primes, so that runs of additions or
+// deletions are applied at once.
+func (w *NonMembershipWitness) Update(pk PublicKey, updates ...Update) error {
+	runs, err := mergeUpdates(updates)
+	if err != nil {
+		return err
+	}
+	return w.update(pk, runs)
+}
+
+// UpdateMembershipWitnesses updates the witnesses after the given updates, in parallel.
+// The witnesses for which an update is invalid are left unchanged, and an error is returned.
+func UpdateMembershipWitnesses(pk PublicKey, witnesses []MembershipWitness, updates ...Update) error {
+	runs, err := mergeUpdates(updates)
+	if err != nil {
+		return err
+	}
+	errs := make([]error, len(witnesses))
+	parallel.Execute(len(witnesses), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = witnesses[i].update(pk, runs)
+		}
+	})
+	return firstError(errs)
+}
+
+// UpdateNonMembershipWitnesses updates the witnesses after the given updates, in parallel.
+// The witnesses for which an update is invalid are left unchanged, and an error is returned.
+func UpdateNonMembershipWitnesses(pk PublicKey, witnesses []NonMembershipWitness, updates ...Update) error {
+	runs, err := mergeUpdates(updates)
+	if err != nil {
+		return err
+	}
+	errs := make([]error, len(witnesses))
+	parallel.Execute(len(witnesses), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = witnesses[i].update(pk, runs)
+		}
+	})
+	return firstError(errs)
+}
+
+func (w *MembershipWitness) update(pk PublicKey, runs []Update) error {
+	x := w.x
+	if x == nil {
+		x = HashToPrime(w.Element)
+	}
+	res := w.W
+	var r big.Int
+	for i := range runs {
+		u := &runs[i]
+		// the primes of the run must all differ from x
+		if r.Mod(u.Prime, x).Sign() == 0 {
+			return ErrInvalidUpdate
+		}
+		if u.Op == OpAdd {
+			res = new(big.Int).Exp(res, u.Prime, pk.N)
+			continue
+		}
+		a, b := bezout(x, u.Prime)
+		if a == nil {
+			return ErrInvalidUpdate
+		}
+		if res = expSigned(res, b, pk.N); res == nil {
+			return ErrInvalidUpdate
+		}
+		res.Mul(res, new(big.Int).Exp(u.Value, a, pk.N)).Mod(res, pk.N)
+	}
+	w.W, w.x = res, x
+	return nil
+}
+
+func (w *NonMembershipWitness) update(pk PublicKey, runs []Update) error {
+	y := w.y
+	if y == nil {
+		y = HashToPrime(w.Element)
+	}
+	resA, resB := w.A, w.B
+	var k big.Int
+	for i := range runs {
+		u := &runs[i]
+		// the primes of the run must all differ from y
+		if k.Mod(u.Prime, y).Sign() == 0 {
+			return ErrInvalidUpdate
+		}
+		if u.Op == OpAdd {
+			a0, r0 := bezout(u.Prime, y)
+			if a0 == nil {
+				return ErrInvalidUpdate
+			}
+			r0.Mul(r0, resA)
+			// Previous comes from the update: it may not be invertible
+			b := expSigned(u.Previous, r0, pk.N)
+			if b == nil {
+				return ErrInvalidUpdate
+			}
+			b.Mul(b, resB)
+			a := new(big.Int).Mul(resA, a0)
+			k.DivMod(a, y, a)
+			b.Mul(b, new(big.Int).Exp(u.Value, &k, pk.N)).Mod(b, pk.N)
+			resA, resB = a, b
+		} else {
+			a := new(big.Int).Mul(resA, u.Prime)
+			k.DivMod(a, y, a)
+			b := new(big.Int).Exp(u.Value, &k, pk.N)
+			b.Mul(b, resB).Mod(b, pk.N)
+			resA, resB = a, b
+		}
+	}
+	w.A, w.B, w.y = resA, resB, y
+	return nil
+}
+
+// mergeUpdates merges the runs of consecutive updates of the same kind into
+// one update, whose Prime is the product of their primes. The updates must be
+// chained: each one starts from the value the previous one ended with.
+func mergeUpdates(updates []Update) ([]Update, error) {
+	var runs []Update
+	for i := range updates {
+		u := &updates[i]
+		if u.Op != OpAdd && u.Op != OpDelete {
+			return nil, ErrInvalidUpdate
+		}
+		if u.Prime == nil || u.Previous == nil || u.Value == nil {
+			return nil, ErrInvalidUpdate
+		}
+		if i != 0 && u.Previous.Cmp(updates[i-1].Value) != 0 {
+			return nil, ErrInvalidUpdate
+		}
+		if len(runs) != 0 && runs[len(runs)-1].Op == u.Op {
+			last := &runs[len(runs)-1]
+			last.Prime.Mul(last.Prime, u.Prime)
+			last.Value = u.Value
+			continue
+		}
+		runs = append(runs, Update{Op: u.Op, Prime: new(big.Int).Set(u.Prime), Previous: u.Previous, Value: u.Value})
+	}
+	return runs, nil
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bezout returns (a, b) such that a·u + b·y = 1 and 0 ≤ a < y, or (nil, nil)
+// if u and y are not coprime.
+func bezout(u, y *big.Int) (a, b *big.Int) {
+	a = new(big.Int).ModInverse(u, y)
+	if a == nil {
+		return nil, nil
+	}
+	// b = (1 - a·u)/y, exactly
+	b = new(big.Int).Mul(a, u)
+	b.Sub(one, b)
+	b.Quo(b, y)
+	return a, b
+}
+
+// expSigned returns base^e mod n, for a possibly negative e. It returns nil if
+// e is negative and base is not invertible modulo n.
+func expSigned(base, e, n *big.Int) *big.Int {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(base, e, n)
+	}
+	res := new(big.Int).Neg(e)
+	res.Exp(base, res, n)
+	return res.ModInverse(res, n)
+}
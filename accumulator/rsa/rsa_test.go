@@ -0,0 +1,196 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rsa
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func elements(n int) [][]byte {
+	res := make([][]byte, n)
+	for i := range res {
+		res[i] = []byte(fmt.Sprintf("element %d", i))
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	assert := require.New(t)
+
+	acc, err := GenerateKey(rand.Reader, 1024)
+	assert.NoError(err)
+	pk := acc.PublicKey()
+	e := elements(6)
+
+	_, err = acc.Add(e[:4]...)
+	assert.NoError(err)
+	_, err = acc.Add(e[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+
+	// invalid batches leave the accumulator unchanged
+	value := acc.Value()
+	_, err = acc.Add(e[4], e[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+	_, err = acc.Add(e[4], e[4])
+	assert.ErrorIs(err, ErrAlreadyMember)
+	_, err = acc.Delete(e[1], e[4])
+	assert.ErrorIs(err, ErrNotMember)
+	_, err = acc.Delete(e[1], e[1])
+	assert.ErrorIs(err, ErrNotMember)
+	assert.Equal(0, value.Cmp(acc.Value()))
+	assert.False(acc.Contains(e[4]))
+	assert.True(acc.Contains(e[1]))
+
+	// membership
+	w, err := acc.MembershipWitness(e[1])
+	assert.NoError(err)
+	assert.NoError(pk.VerifyMembership(acc.Value(), w))
+	_, err = acc.MembershipWitness(e[4])
+	assert.ErrorIs(err, ErrNotMember)
+	forged := w
+	forged.Element = e[4]
+	assert.ErrorIs(pk.VerifyMembership(acc.Value(), forged), ErrVerifyMembership)
+
+	// non-membership
+	nw, err := acc.NonMembershipWitness(e[4])
+	assert.NoError(err)
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), nw))
+	_, err = acc.NonMembershipWitness(e[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+	forgedNonMember := nw
+	forgedNonMember.Element = e[0]
+	assert.ErrorIs(pk.VerifyNonMembership(acc.Value(), forgedNonMember), ErrVerifyNonMembership)
+
+	// deletion
+	_, err = acc.Delete(e[1])
+	assert.NoError(err)
+	assert.ErrorIs(pk.VerifyMembership(acc.Value(), w), ErrVerifyMembership)
+	_, err = acc.Delete(e[1])
+	assert.ErrorIs(err, ErrNotMember)
+	nw, err = acc.NonMembershipWitness(e[1])
+	assert.NoError(err)
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), nw))
+}
+
+func TestWitnessUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	acc, err := GenerateKey(rand.Reader, 1024)
+	assert.NoError(err)
+	pk := acc.PublicKey()
+	e := elements(10)
+
+	_, err = acc.Add(e[:5]...)
+	assert.NoError(err)
+	witnesses := make([]MembershipWitness, 3)
+	for i := range witnesses {
+		witnesses[i], err = acc.MembershipWitness(e[i])
+		assert.NoError(err)
+	}
+	nonMembers := make([]NonMembershipWitness, 2)
+	for i := range nonMembers {
+		nonMembers[i], err = acc.NonMembershipWitness(e[8+i])
+		assert.NoError(err)
+	}
+
+	// additions and deletions, interleaved
+	var updates []Update
+	u, err := acc.Add(e[5:8]...)
+	assert.NoError(err)
+	updates = append(updates, u...)
+	u, err = acc.Delete(e[3], e[6])
+	assert.NoError(err)
+	updates = append(updates, u...)
+	u, err = acc.Add(e[3])
+	assert.NoError(err)
+	updates = append(updates, u...)
+	u, err = acc.Delete(e[4])
+	assert.NoError(err)
+	updates = append(updates, u...)
+
+	assert.NoError(UpdateMembershipWitnesses(pk, witnesses, updates...))
+	for i := range witnesses {
+		assert.NoError(pk.VerifyMembership(acc.Value(), witnesses[i]))
+		expected, err := acc.MembershipWitness(e[i])
+		assert.NoError(err)
+		assert.Equal(0, expected.W.Cmp(witnesses[i].W))
+	}
+	single := nonMembers[0]
+	assert.NoError(UpdateNonMembershipWitnesses(pk, nonMembers, updates...))
+	for i := range nonMembers {
+		assert.NoError(pk.VerifyNonMembership(acc.Value(), nonMembers[i]))
+		assert.True(nonMembers[i].A.Cmp(nonMembers[i].y) < 0, "A must stay reduced")
+	}
+
+	// one update at a time
+	for i := range updates {
+		assert.NoError(single.Update(pk, updates[i]))
+	}
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), single))
+
+	// a witness can't be updated with the addition or deletion of its own element
+	u, err = acc.Delete(e[0], e[1])
+	assert.NoError(err)
+	w := witnesses[0].W
+	assert.ErrorIs(UpdateMembershipWitnesses(pk, witnesses, u...), ErrInvalidUpdate)
+	assert.Equal(0, w.Cmp(witnesses[0].W), "a witness must be left unchanged on error")
+	u, err = acc.Add(e[6], e[8])
+	assert.NoError(err)
+	assert.ErrorIs(nonMembers[0].Update(pk, u...), ErrInvalidUpdate)
+
+	// crafted updates are rejected: a non-invertible Previous, missing values
+	nonMember, err := acc.NonMembershipWitness(e[9])
+	assert.NoError(err)
+	u, err = acc.Add(e[0])
+	assert.NoError(err)
+	crafted := u[0]
+	crafted.Previous = new(big.Int)
+	assert.ErrorIs(nonMember.Update(pk, crafted), ErrInvalidUpdate)
+	crafted.Previous = new(big.Int).Set(pk.N)
+	assert.ErrorIs(nonMember.Update(pk, crafted), ErrInvalidUpdate)
+	crafted.Previous = nil
+	assert.ErrorIs(nonMember.Update(pk, crafted), ErrInvalidUpdate)
+	assert.NoError(nonMember.Update(pk, u...))
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), nonMember))
+
+	// updates out of order, or with a gap, are rejected
+	member, err := acc.MembershipWitness(e[0])
+	assert.NoError(err)
+	first, err := acc.Add(e[1], e[4])
+	assert.NoError(err)
+	second, err := acc.Add(elements(11)[10])
+	assert.NoError(err)
+	assert.ErrorIs(member.Update(pk, first[0], second[0], first[1]), ErrInvalidUpdate)
+	assert.ErrorIs(member.Update(pk, first[0], second[0]), ErrInvalidUpdate)
+	assert.ErrorIs(UpdateMembershipWitnesses(pk, []MembershipWitness{member}, first[1], first[0], second[0]), ErrInvalidUpdate)
+	assert.ErrorIs(nonMember.Update(pk, second[0], first[0], first[1]), ErrInvalidUpdate)
+	assert.NoError(member.Update(pk, append(first, second...)...))
+	assert.NoError(pk.VerifyMembership(acc.Value(), member))
+}
+
+func TestHashToPrime(t *testing.T) {
+	assert := require.New(t)
+
+	p := HashToPrime([]byte("data"))
+	assert.Equal(PrimeBits, p.BitLen())
+	assert.True(p.ProbablyPrime(20))
+	assert.Equal(0, p.Cmp(HashToPrime([]byte("data"))))
+	assert.NotEqual(0, p.Cmp(HashToPrime([]byte("other data"))))
+}
@@ -0,0 +1,400 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrAlreadyMember       = errors.New("element already in the accumulator")
+	ErrNotMember           = errors.New("element not in the accumulator")
+	ErrVerifyMembership    = errors.New("can't verify membership witness")
+	ErrVerifyNonMembership = errors.New("can't verify non-membership witness")
+	ErrInvalidUpdate       = errors.New("invalid update")
+)
+
+// PublicKey of an accumulator
+type PublicKey struct {
+	G1 bls12377.G1Affine    // G₁
+	G2 [2]bls12377.G2Affine // [G₂, [s]G₂]
+}
+
+// Accumulator is a dynamic accumulator, held by its manager who knows the secret key.
+type Accumulator struct {
+	pk       PublicKey
+	s        fr.Element
+	value    bls12377.G1Affine
+	elements map[fr.Element]struct{}
+}
+
+// Operation is the kind of an Update
+type Operation uint8
+
+const (
+	OpAdd Operation = iota
+	OpDelete
+)
+
+// Update describes the addition or deletion of an element. Updates are published
+// by the manager, so that holders of witnesses can update them.
+type Update struct {
+	Op      Operation
+	Element fr.Element
+	Value   bls12377.G1Affine // value of the accumulator after the update
+	// Previous is the value of the accumulator before the update
+	Previous bls12377.G1Affine
+}
+
+// MembershipWitness shows that Element is in the accumulated set: W = [f(s)/(s+x)]G₁.
+type MembershipWitness struct {
+	Element fr.Element
+	W       bls12377.G1Affine
+}
+
+// NonMembershipWitness shows that Element y is not in the accumulated set:
+// f(X) = q(X)(X+y) + D with D ≠ 0, and W = [q(s)]G₁.
+type NonMembershipWitness struct {
+	Element fr.Element
+	W       bls12377.G1Affine
+	D       fr.Element
+}
+
+// New returns an accumulator of the empty set with secret key s, which must be
+// drawn at random and kept secret.
+func New(s *big.Int) (*Accumulator, error) {
+	var acc Accumulator
+	acc.s.SetBigInt(s)
+	if acc.s.IsZero() {
+		return nil, errors.New("the secret key must be non zero")
+	}
+	_, _, g1, g2 := bls12377.Generators()
+	acc.pk.G1 = g1
+	acc.pk.G2[0] = g2
+	acc.pk.G2[1].ScalarMultiplication(&g2, s)
+	acc.value = g1
+	acc.elements = make(map[fr.Element]struct{})
+	return &acc, nil
+}
+
+// PublicKey returns the public key of the accumulator
+func (acc *Accumulator) PublicKey() PublicKey {
+	return acc.pk
+}
+
+// Value returns the current value of the accumulator
+func (acc *Accumulator) Value() bls12377.G1Affine {
+	return acc.value
+}
+
+// Contains returns true if x is in the accumulated set
+func (acc *Accumulator) Contains(x *fr.Element) bool {
+	_, ok := acc.elements[*x]
+	return ok
+}
+
+// Add adds elements to the accumulated set, and returns the corresponding updates.
+// If one of the elements can't be added, the accumulator is left unchanged.
+func (acc *Accumulator) Add(elements ...fr.Element) ([]Update, error) {
+	// validate the whole batch before changing the state
+	factors := make([]fr.Element, len(elements))
+	batch := make(map[fr.Element]struct{}, len(elements))
+	for i := range elements {
+		if _, ok := batch[elements[i]]; ok || acc.Contains(&elements[i]) {
+			return nil, ErrAlreadyMember
+		}
+		batch[elements[i]] = struct{}{}
+		factors[i].Add(&acc.s, &elements[i])
+		if factors[i].IsZero() {
+			return nil, errors.New("can't accumulate -s")
+		}
+	}
+
+	// the i-th value is [∏_{j≤i} (s+xⱼ)]acc
+	for i := 1; i < len(factors); i++ {
+		factors[i].Mul(&factors[i], &factors[i-1])
+	}
+	return acc.apply(OpAdd, elements, factors), nil
+}
+
+// Delete removes elements from the accumulated set, and returns the corresponding updates.
+// If one of the elements can't be removed, the accumulator is left unchanged.
+func (acc *Accumulator) Delete(elements ...fr.Element) ([]Update, error) {
+	// validate the whole batch before changing the state
+	factors := make([]fr.Element, len(elements))
+	batch := make(map[fr.Element]struct{}, len(elements))
+	for i := range elements {
+		if _, ok := batch[elements[i]]; ok || !acc.Contains(&elements[i]) {
+			return nil, ErrNotMember
+		}
+		batch[elements[i]] = struct{}{}
+		factors[i].Add(&acc.s, &elements[i])
+	}
+
+	// the i-th value is [1/∏_{j≤i} (s+xⱼ)]acc; the factors are non zero, as -s
+	// can't be accumulated
+	for i := 1; i < len(factors); i++ {
+		factors[i].Mul(&factors[i], &factors[i-1])
+	}
+	return acc.apply(OpDelete, elements, fr.BatchInvert(factors)), nil
+}
+
+// apply sets the i-th value of the accumulator to [factors[i]]acc, and returns the updates
+func (acc *Accumulator) apply(op Operation, elements, factors []fr.Element) []Update {
+	updates := make([]Update, len(elements))
+	if len(elements) == 0 {
+		return updates
+	}
+	values := bls12377.BatchScalarMultiplicationG1(&acc.value, factors)
+	for i := range elements {
+		updates[i] = Update{Op: op, Element: elements[i], Previous: acc.value, Value: values[i]}
+		if i > 0 {
+			updates[i].Previous = values[i-1]
+		}
+		if op == OpAdd {
+			acc.elements[elements[i]] = struct{}{}
+		} else {
+			delete(acc.elements, elements[i])
+		}
+	}
+	acc.value = values[len(values)-1]
+	return updates
+}
+
+// MembershipWitness returns a witness that x is in the accumulated set
+func (acc *Accumulator) MembershipWitness(x fr.Element) (MembershipWitness, error) {
+	if !acc.Contains(&x) {
+		return MembershipWitness{}, ErrNotMember
+	}
+	var e fr.Element
+	e.Add(&acc.s, &x).Inverse(&e)
+	w := MembershipWitness{Element: x}
+	w.W.ScalarMultiplication(&acc.value, e.BigInt(new(big.Int)))
+	return w, nil
+}
+
+// NonMembershipWitness returns a witness that y is not in the accumulated set
+func (acc *Accumulator) NonMembershipWitness(y fr.Element) (NonMembershipWitness, error) {
+	if acc.Contains(&y) {
+		return NonMembershipWitness{}, ErrAlreadyMember
+	}
+	w := NonMembershipWitness{Element: y}
+
+	// D = f(-y) = ∏ (x - y)
+	w.D.SetOne()
+	var t fr.Element
+	for x := range acc.elements {
+		t.Sub(&x, &y)
+		w.D.Mul(&w.D, &t)
+	}
+
+	// W = [(f(s) - D)/(s+y)]G₁
+	var dG1 bls12377.G1Affine
+	dG1.ScalarMultiplication(&acc.pk.G1, w.D.BigInt(new(big.Int)))
+	w.W.Sub(&acc.value, &dG1)
+	t.Add(&acc.s, &y).Inverse(&t)
+	w.W.ScalarMultiplication(&w.W, t.BigInt(new(big.Int)))
+	return w, nil
+}
+
+// g2Shifted returns [s+x]G₂
+func (pk *PublicKey) g2Shifted(x *fr.Element) bls12377.G2Affine {
+	var res bls12377.G2Affine
+	res.ScalarMultiplication(&pk.G2[0], x.BigInt(new(big.Int)))
+	res.Add(&res, &pk.G2[1])
+	return res
+}
+
+// VerifyMembership checks that w shows that its element is in the set accumulated in value:
+// e(W, [s+x]G₂) = e(value, G₂)
+func (pk *PublicKey) VerifyMembership(value bls12377.G1Affine, w MembershipWitness) error {
+	var negValue bls12377.G1Affine
+	negValue.Neg(&value)
+	ok, err := bls12377.PairingCheck(
+		[]bls12377.G1Affine{w.W, negValue},
+		[]bls12377.G2Affine{pk.g2Shifted(&w.Element), pk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyMembership
+	}
+	return nil
+}
+
+// VerifyNonMembership checks that w shows that its element is not in the set accumulated in value:
+// D ≠ 0 and e(W, [s+y]G₂)·e([D]G₁, G₂) = e(value, G₂)
+func (pk *PublicKey) VerifyNonMembership(value bls12377.G1Affine, w NonMembershipWitness) error {
+	if w.D.IsZero() {
+		return ErrVerifyNonMembership
+	}
+	var dMinusValue bls12377.G1Affine
+	dMinusValue.ScalarMultiplication(&pk.G1, w.D.BigInt(new(big.Int)))
+	dMinusValue.Sub(&dMinusValue, &value)
+	ok, err := bls12377.PairingCheck(
+		[]bls12377.G1Affine{w.W, dMinusValue},
+		[]bls12377.G2Affine{pk.g2Shifted(&w.Element), pk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyNonMembership
+	}
+	return nil
+}
+
+// Update updates the witness after the given updates, in order, without the secret key.
+//
+// After the addition of y, W' = value + [y-x]W, where value is the accumulator before the update.
+// After the deletion of y ≠ x, W' = [1/(y-x)](W - value'), where value' is the accumulator after the update.
+// The updates are composed first, so that W' is computed with a single multi-exponentiation.
+// On error, the witness is left unchanged.
+func (w *MembershipWitness) Update(updates ...Update) error {
+	return w.update(updatePoints(updates), updates, ecc.MultiExpConfig{})
+}
+
+// Update updates the witness after the given updates, in order, without the secret key.
+//
+// After the addition of x ≠ y, W' = value + [x-y]W and D' = (x-y)D, where value is the accumulator before the update.
+// After the deletion of x, W' = [1/(x-y)](W - value') and D' = D/(x-y), where value' is the accumulator after the update.
+// The updates are composed first, so that W' is computed with a single multi-exponentiation.
+// On error, the witness is left unchanged.
+func (w *NonMembershipWitness) Update(updates ...Update) error {
+	return w.update(updatePoints(updates), updates, ecc.MultiExpConfig{})
+}
+
+// UpdateMembershipWitnesses updates the witnesses after the given updates, in parallel.
+// The witnesses for which an update is invalid are left unchanged, and an error is returned.
+func UpdateMembershipWitnesses(witnesses []MembershipWitness, updates ...Update) error {
+	points := updatePoints(updates)
+	errs := make([]error, len(witnesses))
+	parallel.Execute(len(witnesses), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = witnesses[i].update(points, updates, ecc.MultiExpConfig{NbTasks: 1})
+		}
+	})
+	return firstError(errs)
+}
+
+// UpdateNonMembershipWitnesses updates the witnesses after the given updates, in parallel.
+// The witnesses for which an update is invalid are left unchanged, and an error is returned.
+func UpdateNonMembershipWitnesses(witnesses []NonMembershipWitness, updates ...Update) error {
+	points := updatePoints(updates)
+	errs := make([]error, len(witnesses))
+	parallel.Execute(len(witnesses), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = witnesses[i].update(points, updates, ecc.MultiExpConfig{NbTasks: 1})
+		}
+	})
+	return firstError(errs)
+}
+
+func (w *MembershipWitness) update(points []bls12377.G1Affine, updates []Update, config ecc.MultiExpConfig) error {
+	scalars, err := updateScalars(&w.Element, updates)
+	if err != nil {
+		return err
+	}
+	_, err = w.W.MultiExp(withWitness(points, &w.W), scalars, config)
+	return err
+}
+
+func (w *NonMembershipWitness) update(points []bls12377.G1Affine, updates []Update, config ecc.MultiExpConfig) error {
+	scalars, err := updateScalars(&w.Element, updates)
+	if err != nil {
+		return err
+	}
+	if _, err = w.W.MultiExp(withWitness(points, &w.W), scalars, config); err != nil {
+		return err
+	}
+	w.D.Mul(&w.D, &scalars[len(updates)])
+	return nil
+}
+
+// updatePoints returns the points Pᵢ of the updates: the value of the accumulator
+// before the update for an addition, after it for a deletion.
+func updatePoints(updates []Update) []bls12377.G1Affine {
+	res := make([]bls12377.G1Affine, len(updates))
+	for i := range updates {
+		if updates[i].Op == OpAdd {
+			res[i] = updates[i].Previous
+		} else {
+			res[i] = updates[i].Value
+		}
+	}
+	return res
+}
+
+// withWitness returns a copy of points, followed by w. points is shared by the
+// witnesses updated in parallel, and must not be appended to.
+func withWitness(points []bls12377.G1Affine, w *bls12377.G1Affine) []bls12377.G1Affine {
+	res := make([]bls12377.G1Affine, len(points)+1)
+	copy(res, points)
+	res[len(points)] = *w
+	return res
+}
+
+// updateScalars composes the updates of a witness for element x. The i-th update
+// is W ← [aᵢ]W + [bᵢ]Pᵢ, with aᵢ = y-x and bᵢ = 1 for the addition of y, and
+// aᵢ = -bᵢ = 1/(y-x) for its deletion, so that after all the updates
+//
+//	W' = [∏ aᵢ]W + ∑ [bᵢ·∏_{j>i} aⱼ]Pᵢ
+//
+// It returns the coefficients of the Pᵢ, followed by the one of W.
+func updateScalars(x *fr.Element, updates []Update) ([]fr.Element, error) {
+	diffs := make([]fr.Element, len(updates))
+	for i := range updates {
+		if updates[i].Op != OpAdd && updates[i].Op != OpDelete {
+			return nil, ErrInvalidUpdate
+		}
+		diffs[i].Sub(&updates[i].Element, x)
+		if diffs[i].IsZero() {
+			return nil, ErrInvalidUpdate
+		}
+	}
+	inverses := fr.BatchInvert(diffs)
+
+	res := make([]fr.Element, len(updates)+1)
+	a := fr.One()
+	for i := len(updates) - 1; i >= 0; i-- {
+		if updates[i].Op == OpAdd {
+			res[i] = a
+			a.Mul(&a, &diffs[i])
+		} else {
+			a.Mul(&a, &inverses[i])
+			res[i].Neg(&a)
+		}
+	}
+	res[len(updates)] = a
+	return res, nil
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
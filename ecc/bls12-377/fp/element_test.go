@@ -18,10 +18,12 @@ package fp
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"math/bits"
+	"strings"
 
 	mrand "math/rand"
 
@@ -2207,25 +2209,18 @@ func TestElementJSON(t *testing.T) {
 
 	encoded, err := json.Marshal(&s)
 	assert.NoError(err)
-	// we may need to adjust "42" and "8000" values for some moduli; see Text() method for more details.
-	formatValue := func(v int64) string {
+	hexValue := func(v int64) string {
 		var a big.Int
-		a.SetInt64(v)
-		a.Mod(&a, Modulus())
-		const maxUint16 = 65535
-		var aNeg big.Int
-		aNeg.Neg(&a).Mod(&aNeg, Modulus())
-		if aNeg.Uint64() != 0 && aNeg.Uint64() <= maxUint16 {
-			return "-" + aNeg.Text(10)
-		}
-		return a.Text(10)
+		a.SetInt64(v).Mod(&a, Modulus())
+		return "\"0x" + hex.EncodeToString(a.FillBytes(make([]byte, Bytes))) + "\""
 	}
-	expected := fmt.Sprintf("{\"A\":%s,\"B\":[0,0,%s],\"C\":null,\"D\":%s}", formatValue(-1), formatValue(42), formatValue(8000))
+	expected := fmt.Sprintf("{\"A\":%s,\"B\":[%s,%s,%s],\"C\":null,\"D\":%s}",
+		hexValue(-1), hexValue(0), hexValue(0), hexValue(42), hexValue(8000))
 	assert.Equal(expected, string(encoded))
 
 	// decode valid
 	var decoded S
-	err = json.Unmarshal([]byte(expected), &decoded)
+	err = json.Unmarshal(encoded, &decoded)
 	assert.NoError(err)
 
 	assert.Equal(s, decoded, "element -> json -> element round trip failed")
@@ -2239,6 +2234,34 @@ func TestElementJSON(t *testing.T) {
 
 	assert.Equal(s, decodedS, " json with strings  -> element  failed")
 
+	// hex strings are canonical
+	var e Element
+	assert.Error(json.Unmarshal([]byte("\"0x"+Modulus().Text(16)+"\""), &e), "non canonical encoding should be rejected")
+}
+
+func TestElementText(t *testing.T) {
+	assert := require.New(t)
+
+	var a, b Element
+	a.SetString("-1")
+
+	text, err := a.MarshalText()
+	assert.NoError(err)
+	assert.Equal("0x"+hex.EncodeToString(new(big.Int).Sub(Modulus(), big.NewInt(1)).FillBytes(make([]byte, Bytes))), string(text))
+
+	assert.NoError(b.UnmarshalText(text))
+	assert.True(a.Equal(&b), "element -> text -> element round trip failed")
+
+	// optional leading zeroes
+	assert.NoError(b.UnmarshalText([]byte("0X002A")))
+	assert.Equal(uint64(42), b.Uint64())
+
+	// invalid encodings
+	assert.Error(b.UnmarshalText([]byte("2a")), "the 0x prefix is required")
+	assert.Error(b.UnmarshalText([]byte("0x")))
+	assert.Error(b.UnmarshalText([]byte("0xzz")))
+	assert.Error(b.UnmarshalText([]byte("0x"+Modulus().Text(16))), "non canonical encoding should be rejected")
+	assert.Error(b.UnmarshalText([]byte("0x" + strings.Repeat("0", 2*Bytes+1))))
 }
 
 type testPairElement struct {
@@ -31,7 +31,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 )
 
-// Domain with a power of 2 cardinality
+// Domain with a power of 2 cardinality, or a cardinality dividing r-1 (see WithSmoothCardinality, WithExactCardinality)
 // compute a field element of order 2x and store it in FinerGenerator
 // all other values can be derived from x, GeneratorSqrt
 type Domain struct {
@@ -62,6 +62,11 @@ type Domain struct {
 
 	// cosetTable[i][j] = domain.Generator(i-th)SqrtInv ^ j
 	cosetTableInv []fr.Element
+
+	// if the cardinality is not a power of 2, subDomain computes the power of 2 sub transforms of the mixed radix FFT,
+	// or bluestein holds the convolution kernels if the cardinality has a prime factor larger than maxRadix
+	subDomain *Domain
+	bluestein *bluestein
 }
 
 // GeneratorFullMultiplicativeGroup returns a generator of 𝔽ᵣˣ
@@ -75,11 +80,15 @@ func GeneratorFullMultiplicativeGroup() fr.Element {
 
 // NewDomain returns a subgroup with a power of 2 cardinality
 // cardinality >= m
+// With WithSmoothCardinality or WithExactCardinality, the cardinality needs not be a power of 2.
 // shift: when specified, it's the element by which the set of root of unity is shifted.
 func NewDomain(m uint64, opts ...DomainOption) *Domain {
 	opt := domainOptions(opts...)
 	domain := &Domain{}
-	x := ecc.NextPowerOfTwo(m)
+	x, err := domainCardinality(m, opt.cardinality)
+	if err != nil {
+		panic(err)
+	}
 	domain.Cardinality = uint64(x)
 	domain.FrMultiplicativeGen = GeneratorFullMultiplicativeGroup()
 
@@ -88,10 +97,13 @@ func NewDomain(m uint64, opts ...DomainOption) *Domain {
 	}
 	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
 
-	var err error
-	domain.Generator, err = Generator(m)
-	if err != nil {
-		panic(err)
+	if isPowerOfTwo(x) {
+		domain.Generator, err = Generator(x)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		domain.Generator = rootOfUnity(x)
 	}
 	domain.GeneratorInv.Inverse(&domain.Generator)
 	domain.CardinalityInv.SetUint64(uint64(x)).Inverse(&domain.CardinalityInv)
@@ -147,7 +159,23 @@ func (d *Domain) CosetTableInv() ([]fr.Element, error) {
 	return d.cosetTableInv, nil
 }
 
+// domainCardinality returns the cardinality of the domain for m points, following the given policy
+func domainCardinality(m uint64, policy cardinalityPolicy) (uint64, error) {
+	switch policy {
+	case smoothCardinality:
+		return smallestSmoothCardinality(m)
+	case exactCardinality:
+		return checkExactCardinality(m)
+	default:
+		return ecc.NextPowerOfTwo(m), nil
+	}
+}
+
 func (d *Domain) preComputeTwiddles() {
+	if !isPowerOfTwo(d.Cardinality) {
+		d.preComputeMixed()
+		return
+	}
 
 	// nb fft stages
 	nbStages := uint64(bits.TrailingZeros64(d.Cardinality))
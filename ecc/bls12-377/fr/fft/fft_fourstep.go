@@ -0,0 +1,214 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// transposeBlockSize is the side of the square blocks used by the cache-blocked transpositions
+const transposeBlockSize = 16
+
+// fourStep computes the (inverse) FFT of a with Bailey's four-step algorithm.
+//
+// With n = n₁·n₂, a is seen as a n₁×n₂ matrix; the algorithm performs FFTs of size n₁ and n₂ on contiguous rows,
+// separated by cache-blocked transpositions and a twiddle scaling. The bit reversal is never performed explicitly:
+// since rev(k₁ + n₁·k₂) = rev(k₁)·n₂ + rev(k₂), it is absorbed by the order of the sub FFTs and of the
+// transpositions. Coset scaling (and the 1/n scaling of the inverse) are fused into the first (resp. last) pass.
+func (domain *Domain) fourStep(a []fr.Element, decimation Decimation, inverse bool, opt fftConfig) {
+	n := domain.Cardinality
+	log := bits.TrailingZeros64(n)
+	n1 := uint64(1) << ((log + 1) / 2)
+	n2 := n / n1
+
+	w, cosetGen := domain.Generator, domain.FrMultiplicativeGen
+	if inverse {
+		w, cosetGen = domain.GeneratorInv, domain.FrMultiplicativeGenInv
+	}
+	var w1, w2 fr.Element
+	w1.Exp(w, new(big.Int).SetUint64(n2)) // order n1
+	w2.Exp(w, new(big.Int).SetUint64(n1)) // order n2
+	d1 := newPowerOfTwoDomain(n1, w1, true)
+	d2 := newPowerOfTwoDomain(n2, w2, true)
+
+	// coset table, in natural order
+	var coset []fr.Element
+	if opt.coset {
+		switch {
+		case !domain.withPrecompute:
+			coset = make([]fr.Element, n)
+			BuildExpTable(cosetGen, coset)
+		case inverse:
+			coset = domain.cosetTableInv
+		default:
+			coset = domain.cosetTable
+		}
+	}
+
+	// ωᵉ for e < n
+	var half []fr.Element
+	if domain.withPrecompute {
+		if inverse {
+			half = domain.twiddlesInv[0]
+		} else {
+			half = domain.twiddles[0]
+		}
+	}
+	// twiddle multiplies row[pos(k)] by ω^(j·k) for all k
+	twiddle := func(row []fr.Element, j uint64, pos func(uint64) uint64) {
+		if j == 0 {
+			return
+		}
+		if half == nil {
+			var wj fr.Element
+			wj.Exp(w, new(big.Int).SetUint64(j))
+			t := wj
+			for k := uint64(1); k < uint64(len(row)); k++ {
+				row[pos(k)].Mul(&row[pos(k)], &t)
+				t.Mul(&t, &wj)
+			}
+			return
+		}
+		h := n >> 1
+		for k := uint64(1); k < uint64(len(row)); k++ {
+			i := pos(k)
+			if e := j * k; e <= h {
+				row[i].Mul(&row[i], &half[e])
+			} else {
+				row[i].Mul(&row[i], &half[e-h]).Neg(&row[i])
+			}
+		}
+	}
+	natural := func(i uint64) uint64 { return i }
+
+	var scale fr.Element
+	if inverse {
+		scale = domain.CardinalityInv
+	}
+
+	scratch := make([]fr.Element, n)
+	nn := uint64(64 - log)
+	nn1 := uint64(64 - bits.TrailingZeros64(n1))
+
+	if decimation == DIF {
+		// x is in natural order, at row j₁ column j₂ (x[n₂·j₁ + j₂]); the output is in bit reversed order.
+		var scaleIn func(dst *fr.Element, i, j uint64)
+		if opt.coset && !inverse {
+			scaleIn = func(dst *fr.Element, i, _ uint64) { dst.Mul(dst, &coset[i]) }
+		}
+		transpose(scratch, a, n1, n2, scaleIn, opt.nbTasks)
+
+		// scratch[j₂·n₁ + rev(k₁)] = ∑ⱼ₁ x[n₂·j₁ + j₂]ω₁^(j₁·k₁), times ω^(j₂·k₁)
+		parallel.Execute(int(n2), func(start, end int) {
+			for j2 := uint64(start); j2 < uint64(end); j2++ {
+				row := scratch[j2*n1 : (j2+1)*n1]
+				d1.FFT(row, DIF, WithNbTasks(1))
+				twiddle(row, j2, func(i uint64) uint64 { return bits.Reverse64(i) >> nn1 })
+			}
+		}, opt.nbTasks)
+
+		transpose(a, scratch, n2, n1, nil, opt.nbTasks)
+
+		// a[rev(k₁)·n₂ + rev(k₂)] = X[k₁ + n₁·k₂], i.e. a[rev(k)] = X[k]
+		parallel.Execute(int(n1), func(start, end int) {
+			for r := uint64(start); r < uint64(end); r++ {
+				row := a[r*n2 : (r+1)*n2]
+				d2.FFT(row, DIF, WithNbTasks(1))
+				if !inverse {
+					continue
+				}
+				for i := range row {
+					row[i].Mul(&row[i], &scale)
+					if opt.coset {
+						row[i].Mul(&row[i], &coset[bits.Reverse64(r*n2+uint64(i))>>nn])
+					}
+				}
+			}
+		}, opt.nbTasks)
+		return
+	}
+
+	// DIT: a[rev(j)] = x[j]; x[j₁ + n₁·j₂] is at row rev(j₁), column rev(j₂). The output is in natural order.
+	// a[rev(j₁)·n₂ + k₂] = ∑ⱼ₂ x[j₁ + n₁·j₂]ω₂^(j₂·k₂), times ω^(j₁·k₂)
+	parallel.Execute(int(n1), func(start, end int) {
+		for r := uint64(start); r < uint64(end); r++ {
+			row := a[r*n2 : (r+1)*n2]
+			if opt.coset && !inverse {
+				for i := range row {
+					row[i].Mul(&row[i], &coset[bits.Reverse64(r*n2+uint64(i))>>nn])
+				}
+			}
+			d2.FFT(row, DIT, WithNbTasks(1))
+			twiddle(row, bits.Reverse64(r)>>nn1, natural)
+		}
+	}, opt.nbTasks)
+
+	transpose(scratch, a, n1, n2, nil, opt.nbTasks)
+
+	// scratch[k₂·n₁ + k₁] = X[n₂·k₁ + k₂]
+	parallel.Execute(int(n2), func(start, end int) {
+		for k2 := uint64(start); k2 < uint64(end); k2++ {
+			d1.FFT(scratch[k2*n1:(k2+1)*n1], DIT, WithNbTasks(1))
+		}
+	}, opt.nbTasks)
+
+	var scaleOut func(dst *fr.Element, i, j uint64)
+	if inverse {
+		scaleOut = func(dst *fr.Element, _, j uint64) {
+			dst.Mul(dst, &scale)
+			if opt.coset {
+				dst.Mul(dst, &coset[j])
+			}
+		}
+	}
+	transpose(a, scratch, n2, n1, scaleOut, opt.nbTasks)
+}
+
+// transpose sets dst (cols×rows) to the transpose of src (rows×cols), both in row-major order,
+// using square blocks that fit in cache. If scale is not nil, it is called on each element of dst
+// with the indexes i of the element in src and j in dst.
+func transpose(dst, src []fr.Element, rows, cols uint64, scale func(dst *fr.Element, i, j uint64), nbTasks int) {
+	const b = transposeBlockSize
+	nbBlockRows := (rows + b - 1) / b
+	parallel.Execute(int(nbBlockRows), func(start, end int) {
+		for br := uint64(start) * b; br < uint64(end)*b && br < rows; br += b {
+			rEnd := br + b
+			if rEnd > rows {
+				rEnd = rows
+			}
+			for bc := uint64(0); bc < cols; bc += b {
+				cEnd := bc + b
+				if cEnd > cols {
+					cEnd = cols
+				}
+				for r := br; r < rEnd; r++ {
+					for c := bc; c < cEnd; c++ {
+						dst[c*rows+r] = src[r*cols+c]
+						if scale != nil {
+							scale(&dst[c*rows+r], r*cols+c, c*rows+r)
+						}
+					}
+				}
+			}
+		}
+	}, nbTasks)
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// ReadWriterAt is the storage used by the out-of-core FFTs, for instance a *os.File.
+type ReadWriterAt interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// FFTReaderAt computes the FFT of the fr.Vector read from src, without holding it in memory.
+//
+// src must be encoded with fr.Vector.WriteTo (the format read by fr.Vector.AsyncReadFrom), and have
+// exactly domain.Cardinality elements. The result is written to dst in the same format, in natural order;
+// dst is also used as scratch space and must not overlap src.
+//
+// It uses the four-step decomposition n = n₁·n₂: the input is seen as a n₁×n₂ matrix, on which
+// n₂ FFTs of size n₁ (columns), a twiddle scaling and n₁ FFTs of size n₂ (rows) are performed.
+// At most 2·WithChunkSize elements are kept in memory (a block of the matrix, and its transform).
+// Only power of 2 domains are supported.
+func (domain *Domain) FFTReaderAt(dst ReadWriterAt, src io.ReaderAt, opts ...Option) error {
+	return domain.fftReaderAt(dst, src, false, fftOptions(opts...))
+}
+
+// FFTInverseReaderAt computes the inverse FFT of the fr.Vector read from src, without holding it in memory.
+// See FFTReaderAt.
+func (domain *Domain) FFTInverseReaderAt(dst ReadWriterAt, src io.ReaderAt, opts ...Option) error {
+	return domain.fftReaderAt(dst, src, true, fftOptions(opts...))
+}
+
+func (domain *Domain) fftReaderAt(dst ReadWriterAt, src io.ReaderAt, inverse bool, opt fftConfig) error {
+	n := domain.Cardinality
+	if !isPowerOfTwo(n) {
+		return errors.New("out-of-core FFT: domain cardinality must be a power of 2")
+	}
+	var header [4]byte
+	if _, err := src.ReadAt(header[:], 0); err != nil {
+		return err
+	}
+	if uint64(binary.BigEndian.Uint32(header[:])) != n {
+		return fmt.Errorf("out-of-core FFT: expected a vector of %d elements", n)
+	}
+	if _, err := dst.WriteAt(header[:], 0); err != nil {
+		return err
+	}
+
+	w, u := domain.Generator, domain.FrMultiplicativeGen
+	if inverse {
+		w, u = domain.GeneratorInv, domain.FrMultiplicativeGenInv
+	}
+
+	// n = n1·n2; x[n2·j1 + j2] is at row j1 and column j2
+	log := bits.TrailingZeros64(n)
+	n1 := uint64(1) << ((log + 1) / 2)
+	n2 := n / n1
+	var w1, w2 fr.Element
+	w1.Exp(w, new(big.Int).SetUint64(n2)) // order n1
+	w2.Exp(w, new(big.Int).SetUint64(n1)) // order n2
+	d1 := newPowerOfTwoDomain(n1, w1, true)
+	d2 := newPowerOfTwoDomain(n2, w2, true)
+
+	// step 1: FFT of size n1 on the columns, scaled by the twiddles ω^(j2·k1).
+	// The result y[k1][j2] is stored transposed (at j2·n1 + k1) so that each column is written contiguously.
+	nbColumns := uint64(opt.chunkSize) / n1
+	if nbColumns == 0 {
+		nbColumns = 1
+	}
+	if nbColumns > n2 {
+		nbColumns = n2
+	}
+	block := make([]fr.Element, nbColumns*n1)
+	transposed := make([]fr.Element, nbColumns*n1)
+	for c := uint64(0); c < n2; c += nbColumns {
+		b := nbColumns
+		if c+b > n2 {
+			b = n2 - c
+		}
+		// block[j1·b + j] = x[n2·j1 + c + j]
+		for j1 := uint64(0); j1 < n1; j1++ {
+			if err := fr.Vector(block[j1*b:(j1+1)*b]).ReadElementsAt(src, int64(n2*j1+c)); err != nil {
+				return err
+			}
+		}
+		if opt.coset && !inverse {
+			parallel.Execute(int(n1), func(start, end int) {
+				var uj, ujj fr.Element
+				for j1 := uint64(start); j1 < uint64(end); j1++ {
+					uj.Exp(u, new(big.Int).SetUint64(n2*j1+c))
+					ujj = uj
+					for j := uint64(0); j < b; j++ {
+						block[j1*b+j].Mul(&block[j1*b+j], &ujj)
+						ujj.Mul(&ujj, &u)
+					}
+				}
+			}, opt.nbTasks)
+		}
+
+		parallel.Execute(int(b), func(start, end int) {
+			nn := uint64(64 - bits.TrailingZeros64(n1))
+			var t, wj2 fr.Element
+			for j := uint64(start); j < uint64(end); j++ {
+				col := transposed[j*n1 : (j+1)*n1]
+				for j1 := uint64(0); j1 < n1; j1++ {
+					col[bits.Reverse64(j1)>>nn] = block[j1*b+j]
+				}
+				d1.FFT(col, DIT, WithNbTasks(1))
+
+				// twiddles ω^(j2·k1)
+				wj2.Exp(w, new(big.Int).SetUint64(c+j))
+				t.SetOne()
+				for k1 := range col {
+					col[k1].Mul(&col[k1], &t)
+					t.Mul(&t, &wj2)
+				}
+			}
+		}, opt.nbTasks)
+		if err := fr.Vector(transposed[:b*n1]).WriteElementsAt(dst, int64(c*n1)); err != nil {
+			return err
+		}
+	}
+
+	// step 2: FFT of size n2 on the rows; X[k1 + n1·k2] is written in place of y[k1][k2] (at k2·n1 + k1).
+	nbRows := uint64(opt.chunkSize) / n2
+	if nbRows == 0 {
+		nbRows = 1
+	}
+	if nbRows > n1 {
+		nbRows = n1
+	}
+	block = make([]fr.Element, nbRows*n2)
+	rows := make([]fr.Element, nbRows*n2)
+	for r := uint64(0); r < n1; r += nbRows {
+		b := nbRows
+		if r+b > n1 {
+			b = n1 - r
+		}
+		// block[j2·b + k] = y[r + k][j2]
+		for j2 := uint64(0); j2 < n2; j2++ {
+			if err := fr.Vector(block[j2*b:(j2+1)*b]).ReadElementsAt(dst, int64(j2*n1+r)); err != nil {
+				return err
+			}
+		}
+
+		parallel.Execute(int(b), func(start, end int) {
+			nn := uint64(64 - bits.TrailingZeros64(n2))
+			for k := uint64(start); k < uint64(end); k++ {
+				row := rows[k*n2 : (k+1)*n2]
+				for j2 := uint64(0); j2 < n2; j2++ {
+					row[bits.Reverse64(j2)>>nn] = block[j2*b+k]
+				}
+				d2.FFT(row, DIT, WithNbTasks(1))
+			}
+		}, opt.nbTasks)
+
+		// block[k2·b + k] = X[r + k + n1·k2]
+		parallel.Execute(int(n2), func(start, end int) {
+			var s fr.Element
+			for k2 := uint64(start); k2 < uint64(end); k2++ {
+				if inverse && opt.coset {
+					s.Exp(u, new(big.Int).SetUint64(r+n1*k2))
+				}
+				for k := uint64(0); k < b; k++ {
+					block[k2*b+k] = rows[k*n2+k2]
+					if !inverse {
+						continue
+					}
+					block[k2*b+k].Mul(&block[k2*b+k], &domain.CardinalityInv)
+					if opt.coset {
+						block[k2*b+k].Mul(&block[k2*b+k], &s)
+						s.Mul(&s, &u)
+					}
+				}
+			}
+		}, opt.nbTasks)
+
+		for k2 := uint64(0); k2 < n2; k2++ {
+			if err := fr.Vector(block[k2*b:(k2+1)*b]).WriteElementsAt(dst, int64(k2*n1+r)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/sumcheck"
+)
+
+// WriteTo implements io.WriterTo. For each wire, it writes the number of partial sum polynomials as a
+// big endian uint32, then each polynomial and the final evaluation proof encoded as a fr.Vector.
+// The number of wires is written first, as a big endian uint32.
+func (p Proof) WriteTo(w io.Writer) (int64, error) {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(p))); err != nil {
+		return 0, err
+	}
+	n := int64(4)
+
+	for i := range p {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(p[i].PartialSumPolys))); err != nil {
+			return n, err
+		}
+		n += 4
+		for _, poly := range p[i].PartialSumPolys {
+			v := fr.Vector(poly)
+			m, err := v.WriteTo(w)
+			n += m
+			if err != nil {
+				return n, err
+			}
+		}
+
+		var finalEvalProof fr.Vector
+		if p[i].FinalEvalProof != nil {
+			f, ok := p[i].FinalEvalProof.([]fr.Element)
+			if !ok {
+				return n, errors.New("final evaluation proof must be a []fr.Element")
+			}
+			finalEvalProof = f
+		}
+		m, err := finalEvalProof.WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// maxProofLen bounds the lengths read by Proof.ReadFrom
+const maxProofLen = 1 << 20
+
+var errProofTooLarge = errors.New("malformed proof: length exceeds limit")
+
+// ReadFrom implements io.ReaderFrom, reading a proof encoded with WriteTo.
+// The slices are grown as their elements are read, so that a malformed length prefix
+// can't cause an allocation larger than the input.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	readLen := func() (int, error) {
+		var buf [4]byte
+		read, err := io.ReadFull(r, buf[:])
+		n += int64(read)
+		if err != nil {
+			return 0, err
+		}
+		l := binary.BigEndian.Uint32(buf[:])
+		if l > maxProofLen {
+			return 0, errProofTooLarge
+		}
+		return int(l), nil
+	}
+	readVector := func() (fr.Vector, error) {
+		l, err := readLen()
+		if err != nil {
+			return nil, err
+		}
+		var buf [fr.Bytes]byte
+		v := make(fr.Vector, 0)
+		for i := 0; i < l; i++ {
+			read, err := io.ReadFull(r, buf[:])
+			n += int64(read)
+			if err != nil {
+				return nil, err
+			}
+			e, err := fr.BigEndian.Element(&buf)
+			if err != nil {
+				return nil, err
+			}
+			v = append(v, e)
+		}
+		return v, nil
+	}
+
+	nbWires, err := readLen()
+	if err != nil {
+		return n, err
+	}
+	res := make(Proof, 0)
+
+	for i := 0; i < nbWires; i++ {
+		nbPolys, err := readLen()
+		if err != nil {
+			return n, err
+		}
+		polys := make([]polynomial.Polynomial, 0)
+		for j := 0; j < nbPolys; j++ {
+			v, err := readVector()
+			if err != nil {
+				return n, err
+			}
+			polys = append(polys, polynomial.Polynomial(v))
+		}
+
+		finalEvalProof, err := readVector()
+		if err != nil {
+			return n, err
+		}
+
+		res = append(res, sumcheck.Proof{
+			PartialSumPolys: polys,
+			FinalEvalProof:  []fr.Element(finalEvalProof),
+		})
+	}
+	*p = res
+	return n, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/test_vector_utils"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofSerialization(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Cleanup(removeTestGates)
+	assert.NoError(RegisterGate("test-proof-sbox", sBox, 2))
+
+	// two s-box rounds, mixing a wire used twice
+	c := make(Circuit, 4)
+	c[2] = Wire{Gate: GetGate("test-proof-sbox"), Inputs: []*Wire{&c[0], &c[1]}}
+	c[3] = Wire{Gate: GetGate("test-proof-sbox"), Inputs: []*Wire{&c[2], &c[0]}}
+
+	const nbInstances = 1 << 4
+	in0 := make([]fr.Element, nbInstances)
+	in1 := make([]fr.Element, nbInstances)
+	setRandom(in0)
+	setRandom(in1)
+	assignment := WireAssignment{&c[0]: in0, &c[1]: in1}.Complete(c)
+
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+
+	var decoded Proof
+	read, err := decoded.ReadFrom(bytes.NewReader(buf.Bytes()))
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.NoError(proofEquals(proof, decoded))
+
+	err = Verify(c, assignment, decoded, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err, "decoded proof rejected")
+
+	// truncated input
+	_, err = decoded.ReadFrom(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	assert.Error(err)
+
+	// huge length prefixes are rejected before allocating
+	for _, prefix := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff},                         // number of wires
+		{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff},             // number of partial sum polynomials
+		{0, 0, 0, 1, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff}, // polynomial length
+	} {
+		_, err = decoded.ReadFrom(bytes.NewReader(prefix))
+		assert.ErrorIs(err, errProofTooLarge)
+	}
+
+	// lengths within the limit, with no data behind them, don't cause large allocations
+	for _, prefix := range [][]byte{
+		{0, 0x10, 0, 0},                         // number of wires
+		{0, 0, 0, 1, 0, 0x10, 0, 0},             // number of partial sum polynomials
+		{0, 0, 0, 1, 0, 0, 0, 1, 0, 0x10, 0, 0}, // polynomial length
+	} {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		before := stats.TotalAlloc
+		_, err = decoded.ReadFrom(bytes.NewReader(prefix))
+		assert.Error(err)
+		runtime.ReadMemStats(&stats)
+		assert.Less(stats.TotalAlloc-before, uint64(1<<20), "reading a truncated proof allocated too much")
+	}
+
+	// the final evaluation proof of a proof that hasn't been through Prove may be nil
+	proof[0].FinalEvalProof = nil
+	buf.Reset()
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+
+	proof[0].FinalEvalProof = []int{}
+	_, err = proof.WriteTo(&buf)
+	assert.Error(err)
+}
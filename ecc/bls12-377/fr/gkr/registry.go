@@ -0,0 +1,188 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// DefaultMaxGateDegree is the largest degree detected by RegisterGate, unless WithMaxDegree is used
+const DefaultMaxGateDegree = 32
+
+// gatesLock protects Gates in RegisterGate and GetGate
+var gatesLock sync.RWMutex
+
+// GateFunction is a polynomial function of the inputs of a gate
+type GateFunction func(...fr.Element) fr.Element
+
+// registeredGate is a Gate defined by a GateFunction, created by RegisterGate
+type registeredGate struct {
+	evaluate GateFunction
+	nbIn     int
+	degree   int
+}
+
+func (g *registeredGate) Evaluate(x ...fr.Element) fr.Element {
+	if len(x) != g.nbIn {
+		panic(fmt.Sprintf("gate expects %d inputs, got %d", g.nbIn, len(x)))
+	}
+	return g.evaluate(x...)
+}
+
+func (g *registeredGate) Degree() int {
+	return g.degree
+}
+
+type registerGateSettings struct {
+	degree       int // -1 if unknown
+	maxDegree    int
+	skipSelfTest bool
+}
+
+// RegisterGateOption configures RegisterGate
+type RegisterGateOption func(*registerGateSettings)
+
+// WithDegree declares the degree of the gate. RegisterGate fails if the detected degree differs.
+func WithDegree(degree int) RegisterGateOption {
+	return func(s *registerGateSettings) {
+		s.degree = degree
+	}
+}
+
+// WithUnverifiedDegree declares the degree of the gate, and skips the self-test.
+// It is meant for gates that are expensive to evaluate; a wrong degree results in unsound or failing proofs.
+func WithUnverifiedDegree(degree int) RegisterGateOption {
+	return func(s *registerGateSettings) {
+		s.degree = degree
+		s.skipSelfTest = true
+	}
+}
+
+// WithMaxDegree sets the largest degree considered by the degree detection (DefaultMaxGateDegree by default).
+func WithMaxDegree(maxDegree int) RegisterGateOption {
+	return func(s *registerGateSettings) {
+		s.maxDegree = maxDegree
+	}
+}
+
+// RegisterGate adds a gate computing f on nbIn inputs to Gates, under the given name.
+//
+// Unless WithUnverifiedDegree is used, the gate is self-tested: f is evaluated on a random line, and RegisterGate
+// fails if it does not behave as a polynomial of degree at most the max degree. Its total degree is detected from
+// these evaluations, and checked against the one provided with WithDegree if any.
+func RegisterGate(name string, f GateFunction, nbIn int, options ...RegisterGateOption) error {
+	s := registerGateSettings{degree: -1, maxDegree: DefaultMaxGateDegree}
+	for _, opt := range options {
+		opt(&s)
+	}
+	if nbIn < 1 {
+		return errors.New("a gate must have at least one input")
+	}
+
+	g := &registeredGate{evaluate: f, nbIn: nbIn, degree: s.degree}
+	if !s.skipSelfTest {
+		degree, err := detectDegree(f, nbIn, s.maxDegree)
+		if err != nil {
+			return fmt.Errorf("gate \"%s\": %w", name, err)
+		}
+		if s.degree != -1 && s.degree != degree {
+			return fmt.Errorf("gate \"%s\": declared degree %d, detected degree %d", name, s.degree, degree)
+		}
+		g.degree = degree
+	}
+	if g.degree < 0 {
+		return fmt.Errorf("gate \"%s\": unknown degree", name)
+	}
+
+	gatesLock.Lock()
+	defer gatesLock.Unlock()
+	if _, ok := Gates[name]; ok {
+		return fmt.Errorf("gate \"%s\" already registered", name)
+	}
+	Gates[name] = g
+	return nil
+}
+
+// GetGate returns the gate registered under the given name, or nil if there is none.
+func GetGate(name string) Gate {
+	gatesLock.RLock()
+	defer gatesLock.RUnlock()
+	return Gates[name]
+}
+
+// detectDegree returns the degree of f restricted to a random line x + t·y, which is its total degree
+// with high probability. The (k+1)-th finite difference of a polynomial of degree k is zero,
+// and its k-th one is a non-zero constant.
+func detectDegree(f GateFunction, nbIn, maxDegree int) (int, error) {
+	x := make([]fr.Element, nbIn)
+	y := make([]fr.Element, nbIn)
+	for i := range x {
+		if _, err := x[i].SetRandom(); err != nil {
+			return -1, err
+		}
+		if _, err := y[i].SetRandom(); err != nil {
+			return -1, err
+		}
+	}
+
+	// values[t] = f(x + t·y) for t = 0..maxDegree+1
+	values := make([]fr.Element, maxDegree+2)
+	in := make([]fr.Element, nbIn)
+	copy(in, x)
+	for t := range values {
+		values[t] = f(in...)
+		for i := range in {
+			in[i].Add(&in[i], &y[i])
+		}
+	}
+	// f must be deterministic
+	check := f(x...)
+	if !check.Equal(&values[0]) {
+		return -1, errors.New("gate function is not deterministic")
+	}
+
+	degree := -1
+	for k := 0; k < len(values); k++ {
+		zero := true
+		for i := range values[:len(values)-k] {
+			if !values[i].IsZero() {
+				zero = false
+				break
+			}
+		}
+		if zero {
+			break
+		}
+		degree = k
+		for i := 0; i < len(values)-k-1; i++ {
+			values[i].Sub(&values[i+1], &values[i])
+		}
+	}
+
+	if degree > maxDegree {
+		return -1, fmt.Errorf("gate function is not a polynomial of degree at most %d", maxDegree)
+	}
+	if degree == -1 {
+		// the zero gate
+		degree = 0
+	}
+	return degree, nil
+}
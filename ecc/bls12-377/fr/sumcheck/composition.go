@@ -0,0 +1,332 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// Composition is a polynomial C(y₁, ..., yₖ), applied to the values of k multilinear polynomials
+type Composition func(y ...fr.Element) fr.Element
+
+// CompositionClaim is the claim ∑_{x ∈ {0,1}ⁿ} eq(τ, x)·C(f₁(x), ..., fₖ(x)) = Sum, where the eq factor is
+// omitted if Eq is nil.
+//
+// When Eq is provided (e.g. for zero-checks), the prover uses Gruen's optimisation (https://eprint.iacr.org/2024/108):
+// the eq factor of the current variable is kept out of the computation of the round polynomial, whose degree is then
+// that of C, and one of its evaluations is deduced from the current claim.
+type CompositionClaim struct {
+	Composition Composition
+	NbInputs    int          // number k of arguments of Composition
+	Degree      int          // total degree of Composition
+	Eq          []fr.Element // τ, optional
+	Sum         fr.Element
+}
+
+type settings struct {
+	workers *utils.WorkerPool
+}
+
+// Option configures ProveComposition
+type Option func(*settings)
+
+// WithWorkers sets the worker pool used by the prover. By default, a pool is created for the duration of the proof.
+func WithWorkers(workers *utils.WorkerPool) Option {
+	return func(s *settings) {
+		s.workers = workers
+	}
+}
+
+const (
+	// compositionMinBlock is the smallest number of hypercube points processed by a task
+	compositionMinBlock = 512
+	// MaxCompositionDegree is the largest supported degree of a Composition, bounded by
+	// the size of the precomputed Lagrange bases used by polynomial.InterpolateOnRange
+	MaxCompositionDegree = 10
+)
+
+// ProveComposition proves claim, where f are the tables of the multilinear polynomials fᵢ on the hypercube.
+// The tables are folded in place, in linear time; pass clones to keep them.
+// The final evaluations fᵢ(r) are returned in the FinalEvalProof, as a []fr.Element.
+func ProveComposition(claim CompositionClaim, f []polynomial.MultiLin, transcriptSettings fiatshamir.Settings, options ...Option) (Proof, error) {
+	var proof Proof
+	nbVars, err := checkComposition(claim, f)
+	if err != nil {
+		return proof, err
+	}
+	var s settings
+	for _, opt := range options {
+		opt(&s)
+	}
+	if s.workers == nil {
+		s.workers = utils.NewWorkerPool()
+		defer s.workers.Stop()
+	}
+
+	remainingChallengeNames, err := setupTranscript(1, nbVars, &transcriptSettings)
+	if err != nil {
+		return proof, err
+	}
+	transcript := transcriptSettings.Transcript
+
+	// eqTables[j] = eq(τⱼ₊₁, ..., τₙ₋₁; ·), the eq factor of the variables after the j-th
+	var eqTables []polynomial.MultiLin
+	if claim.Eq != nil {
+		eqTables = make([]polynomial.MultiLin, nbVars)
+		for j := range eqTables {
+			eqTables[j] = make(polynomial.MultiLin, 1<<(nbVars-j-1))
+			eqTables[j][0].SetOne()
+			eqTables[j].Eq(claim.Eq[j+1:])
+		}
+	}
+
+	proof.PartialSumPolys = make([]polynomial.Polynomial, nbVars)
+	r := make([]fr.Element, nbVars)
+	gJR := claim.Sum // current claim
+	var alpha fr.Element
+	alpha.SetOne() // eq(τ₀, r₀)···eq(τⱼ₋₁, rⱼ₋₁)
+
+	for j := 0; j < nbVars; j++ {
+		if claim.Eq == nil {
+			// gⱼ(1), ..., gⱼ(d)
+			proof.PartialSumPolys[j] = roundEvaluations(claim.Composition, f, nil, 1, claim.Degree, false, s.workers)
+		} else {
+			proof.PartialSumPolys[j] = gruenRound(claim, f, eqTables[j], &alpha, &gJR, j, s.workers)
+		}
+
+		if r[j], err = next(transcript, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
+			return proof, err
+		}
+
+		var g0 fr.Element
+		g0.Sub(&gJR, &proof.PartialSumPolys[j][0])
+		gJ := polynomial.InterpolateOnRange(append([]fr.Element{g0}, proof.PartialSumPolys[j]...))
+		gJR = gJ.Eval(&r[j])
+		if claim.Eq != nil {
+			e := eqAt(&claim.Eq[j], &r[j])
+			alpha.Mul(&alpha, &e)
+		}
+
+		folds := make([]*sync.WaitGroup, len(f))
+		for i := range f {
+			fold := f[i].FoldParallel(r[j])
+			folds[i] = s.workers.Submit(len(f[i]), fold, compositionMinBlock)
+		}
+		for _, wg := range folds {
+			wg.Wait()
+		}
+	}
+
+	finalEvaluations := make([]fr.Element, len(f))
+	for i := range f {
+		finalEvaluations[i] = f[i][0]
+	}
+	proof.FinalEvalProof = finalEvaluations
+	return proof, nil
+}
+
+// VerifyComposition checks a proof of claim over nbVars variables. It returns the random point r and the evaluations
+// fᵢ(r) claimed by the prover, which the caller must check, e.g. against commitments to the fᵢ.
+func VerifyComposition(claim CompositionClaim, nbVars int, proof Proof, transcriptSettings fiatshamir.Settings) (r, evaluations []fr.Element, err error) {
+	if err = checkClaim(claim, nbVars); err != nil {
+		return nil, nil, err
+	}
+	if len(proof.PartialSumPolys) != nbVars {
+		return nil, nil, errors.New("malformed proof: wrong number of rounds")
+	}
+	evaluations, ok := proof.FinalEvalProof.([]fr.Element)
+	if !ok {
+		return nil, nil, errors.New("malformed proof: final evaluations must be a []fr.Element")
+	}
+	if len(evaluations) != claim.NbInputs {
+		return nil, nil, errors.New("malformed proof: wrong number of final evaluations")
+	}
+
+	remainingChallengeNames, err := setupTranscript(1, nbVars, &transcriptSettings)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript := transcriptSettings.Transcript
+
+	degree := claim.Degree
+	if claim.Eq != nil {
+		degree++
+	}
+	r = make([]fr.Element, nbVars)
+	gJR := claim.Sum
+	for j := 0; j < nbVars; j++ {
+		if len(proof.PartialSumPolys[j]) != degree {
+			return nil, nil, errors.New("malformed proof: wrong round polynomial degree")
+		}
+		if r[j], err = next(transcript, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
+			return nil, nil, err
+		}
+		// gⱼ(0) + gⱼ(1) = gⱼ₋₁(rⱼ₋₁)
+		var g0 fr.Element
+		g0.Sub(&gJR, &proof.PartialSumPolys[j][0])
+		gJ := polynomial.InterpolateOnRange(append([]fr.Element{g0}, proof.PartialSumPolys[j]...))
+		gJR = gJ.Eval(&r[j])
+	}
+
+	expected := claim.Composition(evaluations...)
+	if claim.Eq != nil {
+		e := polynomial.EvalEq(claim.Eq, r)
+		expected.Mul(&expected, &e)
+	}
+	if !expected.Equal(&gJR) {
+		return nil, nil, errors.New("sumcheck final evaluation mismatch")
+	}
+	return r, evaluations, nil
+}
+
+// gruenRound returns the evaluations at 1, ..., d+1 of gⱼ(X) = α·eq(τⱼ, X)·qⱼ(X), where
+// qⱼ(X) = ∑ₕ eq(τ_{>j}, h)·C(f(X, h)). qⱼ(1) is deduced from gⱼ(0) + gⱼ(1) = claim when possible.
+func gruenRound(claim CompositionClaim, f []polynomial.MultiLin, eqTable polynomial.MultiLin, alpha, gJR *fr.Element, j int, workers *utils.WorkerPool) polynomial.Polynomial {
+	d := claim.Degree
+	tau := &claim.Eq[j]
+
+	// q[t] = qⱼ(t) for t = 0..d
+	var q polynomial.Polynomial
+	var denominator fr.Element
+	denominator.Mul(alpha, tau)
+	if denominator.IsZero() {
+		q = roundEvaluations(claim.Composition, f, eqTable, 0, d, false, workers)
+	} else {
+		q = roundEvaluations(claim.Composition, f, eqTable, 0, d, true, workers)
+		// claim = α·((1-τ)q(0) + τ·q(1))
+		var t, one fr.Element
+		one.SetOne()
+		t.Sub(&one, tau).Mul(&t, &q[0]).Mul(&t, alpha)
+		q[1].Sub(gJR, &t)
+		denominator.Inverse(&denominator)
+		q[1].Mul(&q[1], &denominator)
+	}
+
+	// qⱼ(d+1), by extrapolation
+	qCoeffs := polynomial.InterpolateOnRange(q)
+	var x fr.Element
+	x.SetUint64(uint64(d + 1))
+	q = append(q, qCoeffs.Eval(&x))
+
+	res := make(polynomial.Polynomial, d+1)
+	for t := 1; t <= d+1; t++ {
+		x.SetUint64(uint64(t))
+		e := eqAt(tau, &x)
+		res[t-1].Mul(&e, &q[t]).Mul(&res[t-1], alpha)
+	}
+	return res
+}
+
+// roundEvaluations returns [∑ₕ w(h)·C(f(t, h)) for t = from..to], where f(t, h) is the linear extrapolation in the first
+// variable, and w = 1 if nil. If skipOne is set, the evaluation at 1 is not computed (and left to zero).
+func roundEvaluations(c Composition, f []polynomial.MultiLin, w polynomial.MultiLin, from, to int, skipOne bool, workers *utils.WorkerPool) polynomial.Polynomial {
+	mid := len(f[0]) / 2
+	res := make(polynomial.Polynomial, to-from+1)
+	var lock sync.Mutex
+
+	workers.Submit(mid, func(start, end int) {
+		partial := make([]fr.Element, len(res))
+		y := make([]fr.Element, len(f))
+		delta := make([]fr.Element, len(f))
+		var v fr.Element
+		for h := start; h < end; h++ {
+			for i := range f {
+				delta[i].Sub(&f[i][mid+h], &f[i][h])
+				// y = f(from, h)
+				switch from {
+				case 0:
+					y[i] = f[i][h]
+				case 1:
+					y[i] = f[i][mid+h]
+				}
+			}
+			for t := from; t <= to; t++ {
+				if t > from {
+					for i := range y {
+						y[i].Add(&y[i], &delta[i])
+					}
+				}
+				if skipOne && t == 1 {
+					continue
+				}
+				v = c(y...)
+				if w != nil {
+					v.Mul(&v, &w[h])
+				}
+				partial[t-from].Add(&partial[t-from], &v)
+			}
+		}
+		lock.Lock()
+		for i := range res {
+			res[i].Add(&res[i], &partial[i])
+		}
+		lock.Unlock()
+	}, compositionMinBlock).Wait()
+
+	return res
+}
+
+// eqAt returns eq(τ, x) = (1-τ)(1-x) + τx
+func eqAt(tau, x *fr.Element) fr.Element {
+	var res, t fr.Element
+	res.SetOne()
+	res.Sub(&res, tau).Sub(&res, x)
+	t.Mul(tau, x).Double(&t)
+	res.Add(&res, &t)
+	return res
+}
+
+// checkComposition checks that the tables have the same power of 2 size, and returns the number of variables
+func checkComposition(claim CompositionClaim, f []polynomial.MultiLin) (int, error) {
+	if len(f) == 0 {
+		return 0, errors.New("at least one multilinear polynomial is needed")
+	}
+	nbVars := f[0].NumVars()
+	if nbVars == 0 || len(f[0]) != 1<<nbVars {
+		return 0, errors.New("the tables must have a power of 2 size, at least 2")
+	}
+	for i := range f {
+		if len(f[i]) != len(f[0]) {
+			return 0, errors.New("the tables must have the same size")
+		}
+	}
+	if len(f) != claim.NbInputs {
+		return 0, fmt.Errorf("the composition takes %d inputs, got %d tables", claim.NbInputs, len(f))
+	}
+	return nbVars, checkClaim(claim, nbVars)
+}
+
+// checkClaim checks that claim is well-formed for nbVars variables
+func checkClaim(claim CompositionClaim, nbVars int) error {
+	if claim.NbInputs < 1 {
+		return errors.New("the composition must take at least one input")
+	}
+	if claim.Eq != nil && len(claim.Eq) != nbVars {
+		return fmt.Errorf("τ must have %d coordinates", nbVars)
+	}
+	if claim.Degree < 1 || claim.Degree > MaxCompositionDegree {
+		return fmt.Errorf("the composition degree must be between 1 and %d", MaxCompositionDegree)
+	}
+	return nil
+}
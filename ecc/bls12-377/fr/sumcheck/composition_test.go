@@ -0,0 +1,201 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/test_vector_utils"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// cubic is C(y₁, y₂, y₃) = y₁·y₂·y₃ + y₁
+func cubic(y ...fr.Element) fr.Element {
+	var res fr.Element
+	res.Mul(&y[0], &y[1]).Mul(&res, &y[2]).Add(&res, &y[0])
+	return res
+}
+
+func randomMultiLins(nbPolys, nbVars int) []polynomial.MultiLin {
+	res := make([]polynomial.MultiLin, nbPolys)
+	for i := range res {
+		res[i] = make(polynomial.MultiLin, 1<<nbVars)
+		for j := range res[i] {
+			res[i][j].SetRandom()
+		}
+	}
+	return res
+}
+
+// compositionSum returns ∑ₓ eq(τ, x)·C(f(x)), with no eq factor if τ is nil
+func compositionSum(c Composition, f []polynomial.MultiLin, tau []fr.Element) fr.Element {
+	var eq polynomial.MultiLin
+	if tau != nil {
+		eq = make(polynomial.MultiLin, len(f[0]))
+		eq[0].SetOne()
+		eq.Eq(tau)
+	}
+	var sum fr.Element
+	y := make([]fr.Element, len(f))
+	for x := range f[0] {
+		for i := range f {
+			y[i] = f[i][x]
+		}
+		v := c(y...)
+		if eq != nil {
+			v.Mul(&v, &eq[x])
+		}
+		sum.Add(&sum, &v)
+	}
+	return sum
+}
+
+func testComposition(t *testing.T, nbVars int, withEq bool, options ...Option) {
+	assert := assert.New(t)
+
+	f := randomMultiLins(3, nbVars)
+	claim := CompositionClaim{Composition: cubic, NbInputs: 3, Degree: 3}
+	if withEq {
+		claim.Eq = make([]fr.Element, nbVars)
+		for i := range claim.Eq {
+			claim.Eq[i].SetRandom()
+		}
+	}
+	claim.Sum = compositionSum(cubic, f, claim.Eq)
+
+	fClone := make([]polynomial.MultiLin, len(f))
+	for i := range f {
+		fClone[i] = f[i].Clone()
+	}
+
+	proof, err := ProveComposition(claim, fClone, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)), options...)
+	assert.NoError(err)
+
+	r, evaluations, err := VerifyComposition(claim, nbVars, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+	for i := range f {
+		expected := f[i].Evaluate(r, nil)
+		assert.True(expected.Equal(&evaluations[i]), "final evaluation of f%d", i)
+	}
+
+	// wrong sum
+	var one fr.Element
+	one.SetOne()
+	claim.Sum.Add(&claim.Sum, &one)
+	_, _, err = VerifyComposition(claim, nbVars, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+	claim.Sum.Sub(&claim.Sum, &one)
+
+	// tampered round polynomial
+	proof.PartialSumPolys[nbVars-1][0].Add(&proof.PartialSumPolys[nbVars-1][0], &one)
+	_, _, err = VerifyComposition(claim, nbVars, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+}
+
+func TestProveComposition(t *testing.T) {
+	for _, nbVars := range []int{1, 2, 5} {
+		testComposition(t, nbVars, false)
+	}
+}
+
+func TestProveCompositionEq(t *testing.T) {
+	for _, nbVars := range []int{1, 2, 5} {
+		testComposition(t, nbVars, true)
+	}
+}
+
+func TestProveCompositionWorkers(t *testing.T) {
+	workers := utils.NewWorkerPool()
+	defer workers.Stop()
+	testComposition(t, 11, true, WithWorkers(workers))
+}
+
+func TestProveCompositionDegree(t *testing.T) {
+	assert := assert.New(t)
+
+	f := randomMultiLins(3, 3)
+	claim := CompositionClaim{Composition: cubic, NbInputs: 3, Degree: 3, Sum: compositionSum(cubic, f, nil)}
+	proof, err := ProveComposition(claim, f, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+	for _, p := range proof.PartialSumPolys {
+		assert.Equal(claim.Degree, len(p))
+	}
+
+	// an underestimated degree yields a rejected proof
+	f = randomMultiLins(3, 3)
+	claim.Degree = 2
+	claim.Sum = compositionSum(cubic, f, nil)
+	proof, err = ProveComposition(claim, f, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+	_, _, err = VerifyComposition(claim, 3, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+
+	claim.Degree = MaxCompositionDegree + 1
+	_, err = ProveComposition(claim, randomMultiLins(3, 3), fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+	_, _, err = VerifyComposition(claim, 3, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+}
+
+func TestVerifyCompositionMalformedProof(t *testing.T) {
+	assert := assert.New(t)
+
+	f := randomMultiLins(3, 3)
+	claim := CompositionClaim{Composition: cubic, NbInputs: 3, Degree: 3, Sum: compositionSum(cubic, f, nil)}
+	proof, err := ProveComposition(claim, f, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+
+	// cubic reads its three arguments: fewer final evaluations must be rejected, not panic
+	evaluations := proof.FinalEvalProof.([]fr.Element)
+	for _, finalEvalProof := range [][]fr.Element{nil, evaluations[:2], append(evaluations, evaluations[0])} {
+		proof.FinalEvalProof = finalEvalProof
+		_, _, err = VerifyComposition(claim, 3, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.Error(err)
+	}
+
+	_, err = ProveComposition(claim, randomMultiLins(2, 3), fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err, "the number of tables must match NbInputs")
+}
+
+func BenchmarkProveComposition(b *testing.B) {
+	const nbVars = 16
+	f := randomMultiLins(3, nbVars)
+	tau := make([]fr.Element, nbVars)
+	for i := range tau {
+		tau[i].SetRandom()
+	}
+	claim := CompositionClaim{Composition: cubic, NbInputs: 3, Degree: 3, Eq: tau, Sum: compositionSum(cubic, f, tau)}
+	workers := utils.NewWorkerPool()
+	defer workers.Stop()
+
+	fClone := make([]polynomial.MultiLin, len(f))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for j := range f {
+			fClone[j] = f[j].Clone()
+		}
+		b.StartTimer()
+		if _, err := ProveComposition(claim, fClone, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)), WithWorkers(workers)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hiding
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrInvalidBlindingSize = errors.New("invalid blinding polynomial size (larger than SRS or == 0)")
+	ErrInvalidNbBlindings  = errors.New("number of blinding polynomials is not the same as the number of polynomials")
+)
+
+// domain separation tag used to derive H
+const hDST = "GNARK-CRYPTO-KZG-HIDING-V01"
+
+// Digest commitment of a polynomial.
+type Digest = kzg.Digest
+
+// ProvingKey used to create or open commitments
+type ProvingKey struct {
+	kzg.ProvingKey                     // [G₁ [α]G₁ , [α²]G₁, ... ]
+	H              []bls12377.G1Affine // [H [α]H , [α²]H, ... ]
+}
+
+// VerifyingKey used to verify opening proofs
+type VerifyingKey struct {
+	kzg.VerifyingKey
+	H bls12377.G1Affine
+}
+
+// SRS must be computed through MPC and comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// OpeningProof hiding KZG proof for opening at a single point.
+type OpeningProof struct {
+	// H commitment to the quotients (f - f(z))/(x-z) and (r̂ - r̂(z))/(x-z)
+	H bls12377.G1Affine
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+
+	// BlindingValue evaluation of the blinding polynomial r̂(z)
+	BlindingValue fr.Element
+}
+
+// BatchOpeningProof hiding opening proof for many polynomials at the same point
+type BatchOpeningProof struct {
+	// H commitment to the quotients of Sum_i gamma**i*f and Sum_i gamma**i*r̂
+	H bls12377.G1Affine
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+
+	// BlindingValues evaluations of the blinding polynomials
+	BlindingValues []fr.Element
+}
+
+// NewSRS returns a new SRS using alpha as randomness source. The second
+// generator H is hashed to the curve, so that its discrete logarithm is unknown.
+//
+// In production, a SRS generated through MPC should be used.
+//
+// As in kzg.NewSRS, set Alpha = -1 to generate quickly a balanced, valid SRS (useful for benchmarking).
+func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
+	srs, err := kzg.NewSRS(size, bAlpha)
+	if err != nil {
+		return nil, err
+	}
+	h, err := bls12377.HashToG1([]byte("H"), []byte(hDST))
+	if err != nil {
+		return nil, err
+	}
+
+	var alpha fr.Element
+	if bAlpha.Cmp(big.NewInt(-1)) == 0 {
+		// same element of order 4 as kzg.NewSRS
+		if alpha, err = fr.Generator(4); err != nil {
+			return nil, err
+		}
+	} else {
+		alpha.SetBigInt(bAlpha)
+	}
+
+	var res SRS
+	res.Pk.ProvingKey = srs.Pk
+	res.Vk.VerifyingKey = srs.Vk
+	res.Vk.H = h
+
+	res.Pk.H = make([]bls12377.G1Affine, size)
+	res.Pk.H[0] = h
+	alphas := make([]fr.Element, size-1)
+	alphas[0] = alpha
+	for i := 1; i < len(alphas); i++ {
+		alphas[i].Mul(&alphas[i-1], &alpha)
+	}
+	copy(res.Pk.H[1:], bls12377.BatchScalarMultiplicationG1(&h, alphas))
+
+	return &res, nil
+}
+
+// Commit commits to a polynomial with a random blinding polynomial of degree
+// hidingBound, that it returns: the commitment stays hiding after up to
+// hidingBound openings.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func Commit(p []fr.Element, hidingBound int, pk ProvingKey) (Digest, []fr.Element, error) {
+	if hidingBound < 0 || hidingBound >= len(pk.H) {
+		return Digest{}, nil, ErrInvalidBlindingSize
+	}
+	blinding := make([]fr.Element, hidingBound+1)
+	for i := range blinding {
+		if _, err := blinding[i].SetRandom(); err != nil {
+			return Digest{}, nil, err
+		}
+	}
+	digest, err := CommitWithBlinding(p, blinding, pk)
+	if err != nil {
+		return Digest{}, nil, err
+	}
+	return digest, blinding, nil
+}
+
+// CommitWithBlinding commits to a polynomial with the given blinding polynomial:
+// [p(α)]G₁ + [r̂(α)]H
+func CommitWithBlinding(p, blinding []fr.Element, pk ProvingKey) (Digest, error) {
+	if len(blinding) == 0 || len(blinding) > len(pk.H) {
+		return Digest{}, ErrInvalidBlindingSize
+	}
+	res, err := kzg.Commit(p, pk.ProvingKey)
+	if err != nil {
+		return Digest{}, err
+	}
+	var mask Digest
+	if _, err := mask.MultiExp(pk.H[:len(blinding)], blinding, ecc.MultiExpConfig{}); err != nil {
+		return Digest{}, err
+	}
+	res.Add(&res, &mask)
+	return res, nil
+}
+
+// Open computes an opening proof of polynomial p, committed with the given
+// blinding polynomial, at given point.
+func Open(p, blinding []fr.Element, point fr.Element, pk ProvingKey) (OpeningProof, error) {
+	if len(blinding) == 0 || len(blinding) > len(pk.H) {
+		return OpeningProof{}, ErrInvalidBlindingSize
+	}
+	proof, err := kzg.Open(p, point, pk.ProvingKey)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// the blinding polynomial is opened against the powers of H
+	var res OpeningProof
+	res.ClaimedValue = proof.ClaimedValue
+	if len(blinding) == 1 {
+		// constant blinding, the quotient is zero
+		res.H = proof.H
+		res.BlindingValue = blinding[0]
+		return res, nil
+	}
+	blindingProof, err := kzg.Open(blinding, point, kzg.ProvingKey{G1: pk.H})
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	res.H.Add(&proof.H, &blindingProof.H)
+	res.BlindingValue = blindingProof.ClaimedValue
+
+	return res, nil
+}
+
+// unblind returns the commitment minus [r̂(z)]H, which is a (binding) KZG
+// commitment to p opened by proof.
+func unblind(commitment *Digest, proof *OpeningProof, vk *VerifyingKey) (Digest, kzg.OpeningProof) {
+	var mask, res Digest
+	mask.ScalarMultiplication(&vk.H, proof.BlindingValue.BigInt(new(big.Int)))
+	res.Sub(commitment, &mask)
+	return res, kzg.OpeningProof{H: proof.H, ClaimedValue: proof.ClaimedValue}
+}
+
+// Verify verifies a hiding KZG opening proof at a single point:
+// e([f(α) + r̂(α)γ - f(a) - r̂(a)γ + aH(α)]G₁, G₂).e([-H(α)]G₁, [α]G₂) == 1, where H = [γ]G₁
+func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, vk VerifyingKey) error {
+	digest, kzgProof := unblind(commitment, proof, &vk)
+	return kzg.Verify(&digest, &kzgProof, point, vk.VerifyingKey)
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at point of a list of polynomials,
+// committed with the given blinding polynomials.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * point is the point at which the polynomials are opened.
+// * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
+// * polynomials is the list of polynomials to open.
+// * blindings is the list of the corresponding blinding polynomials.
+// * dataTranscript extra data that might be needed to derive the challenge used for folding
+func BatchOpenSinglePoint(polynomials, blindings [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, pk ProvingKey, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+
+	// check for invalid sizes
+	if len(digests) != len(polynomials) {
+		return BatchOpeningProof{}, kzg.ErrInvalidNbDigests
+	}
+	if len(blindings) != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbBlindings
+	}
+	if len(digests) == 0 {
+		return BatchOpeningProof{}, kzg.ErrZeroNbDigests
+	}
+	largestPoly, largestBlinding := 0, 0
+	for i := range polynomials {
+		if len(polynomials[i]) == 0 || len(polynomials[i]) > len(pk.G1) {
+			return BatchOpeningProof{}, kzg.ErrInvalidPolynomialSize
+		}
+		if len(blindings[i]) == 0 || len(blindings[i]) > len(pk.H) {
+			return BatchOpeningProof{}, ErrInvalidBlindingSize
+		}
+		if len(polynomials[i]) > largestPoly {
+			largestPoly = len(polynomials[i])
+		}
+		if len(blindings[i]) > largestBlinding {
+			largestBlinding = len(blindings[i])
+		}
+	}
+
+	// compute the purported values
+	res := BatchOpeningProof{
+		ClaimedValues:  make([]fr.Element, len(polynomials)),
+		BlindingValues: make([]fr.Element, len(polynomials)),
+	}
+	for i := range polynomials {
+		res.ClaimedValues[i] = eval(polynomials[i], point)
+		res.BlindingValues[i] = eval(blindings[i], point)
+	}
+
+	// derive the challenge γ, binded to the point, the commitments and the blinding values
+	gamma, err := deriveGamma(point, digests, res.ClaimedValues, res.BlindingValues, hf, dataTranscript...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// open ∑ᵢγⁱfᵢ, blinded with ∑ᵢγⁱr̂ᵢ
+	proof, err := Open(foldPolynomials(polynomials, largestPoly, gamma), foldPolynomials(blindings, largestBlinding, gamma), point, pk)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+	res.H = proof.H
+
+	return res, nil
+}
+
+// FoldProof fold the digests and the proofs in batchOpeningProof using Fiat Shamir
+// to obtain an opening proof at a single point.
+//
+// * digests list of digests on which batchOpeningProof is based
+// * batchOpeningProof opening proof of digests
+// * transcript extra data needed to derive the challenge used for folding.
+// * returns the folded version of batchOpeningProof, Digest, the folded version of digests
+func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, Digest, error) {
+
+	nbDigests := len(digests)
+
+	// check consistency between numbers of claims vs number of digests
+	if nbDigests != len(batchOpeningProof.ClaimedValues) || nbDigests != len(batchOpeningProof.BlindingValues) {
+		return OpeningProof{}, Digest{}, kzg.ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return OpeningProof{}, Digest{}, kzg.ErrZeroNbDigests
+	}
+
+	// derive the challenge γ, binded to the point, the commitments and the blinding values
+	gamma, err := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, batchOpeningProof.BlindingValues, hf, dataTranscript...)
+	if err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+
+	// gammai = [1,γ,γ²,..,γⁿ⁻¹]
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	// fold the digests, the claimed values and the blinding values
+	var res OpeningProof
+	var foldedDigests Digest
+	if _, err := foldedDigests.MultiExp(digests, gammai, ecc.MultiExpConfig{}); err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+	var tmp fr.Element
+	for i := 0; i < nbDigests; i++ {
+		tmp.Mul(&batchOpeningProof.ClaimedValues[i], &gammai[i])
+		res.ClaimedValue.Add(&res.ClaimedValue, &tmp)
+		tmp.Mul(&batchOpeningProof.BlindingValues[i], &gammai[i])
+		res.BlindingValue.Add(&res.BlindingValue, &tmp)
+	}
+	res.H = batchOpeningProof.H
+
+	return res, foldedDigests, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+//
+// * digests list of digests on which opening proof is done
+// * batchOpeningProof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenge used for the folding
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+
+	// fold the proof
+	foldedProof, foldedDigest, err := FoldProof(digests, batchOpeningProof, point, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// verify the foldedProof against the foldedDigest
+	return Verify(&foldedDigest, &foldedProof, point, vk)
+}
+
+// BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
+// The purpose of the batching is to have only one pairing for verifying several proofs.
+//
+// * digests list of committed polynomials
+// * proofs list of opening proofs, one for each digest
+// * points the list of points at which the opening are done
+func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr.Element, vk VerifyingKey) error {
+
+	// check consistency nb proofs vs nb digests
+	if len(digests) != len(proofs) {
+		return kzg.ErrInvalidNbDigests
+	}
+
+	// the unblinded digests are binding KZG commitments
+	kzgDigests := make([]Digest, len(digests))
+	kzgProofs := make([]kzg.OpeningProof, len(proofs))
+	for i := range digests {
+		kzgDigests[i], kzgProofs[i] = unblind(&digests[i], &proofs[i], &vk)
+	}
+
+	return kzg.BatchVerifyMultiPoints(kzgDigests, kzgProofs, points, vk.VerifyingKey)
+}
+
+// foldPolynomials returns ∑ᵢγⁱpᵢ, of the given size
+func foldPolynomials(polynomials [][]fr.Element, size int, gamma fr.Element) []fr.Element {
+	res := make([]fr.Element, size)
+	var gammai, tmp fr.Element
+	gammai.SetOne()
+	for i := range polynomials {
+		for j := range polynomials[i] {
+			tmp.Mul(&polynomials[i][j], &gammai)
+			res[j].Add(&res[j], &tmp)
+		}
+		gammai.Mul(&gammai, &gamma)
+	}
+	return res
+}
+
+// eval returns p(point) where p is interpreted as a polynomial
+// ∑_{i<len(p)}p[i]Xⁱ
+func eval(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	n := len(p)
+	res.Set(&p[n-1])
+	for i := n - 2; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold proofs.
+func deriveGamma(point fr.Element, digests []Digest, claimedValues, blindingValues []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+
+	// derive the challenge gamma, binded to the point, the commitments and the blinding values
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	if err := fs.Bind("gamma", point.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range claimedValues {
+		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range blindingValues {
+		if err := fs.Bind("gamma", blindingValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	for i := 0; i < len(dataTranscript); i++ {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	return gamma, nil
+}
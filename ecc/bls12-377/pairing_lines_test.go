@@ -0,0 +1,89 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestLineCache(t *testing.T) {
+	t.Parallel()
+
+	Q := make([]G2Affine, 4)
+	for i := range Q {
+		Q[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+2)))
+	}
+
+	cache := NewLineCache(2)
+	if _, ok := cache.Lookup(&Q[0]); ok {
+		t.Fatal("empty cache should miss")
+	}
+
+	lines := cache.Get(&Q[0])
+	if lines != PrecomputeLines(Q[0]) {
+		t.Fatal("Get should return PrecomputeLines(Q)")
+	}
+	cache.Get(&Q[1])
+	cache.Get(&Q[0]) // Q[1] is now the least recently used
+	cache.Get(&Q[2])
+	if cache.Len() != 2 {
+		t.Fatal("cache should not exceed its capacity")
+	}
+	if _, ok := cache.Lookup(&Q[1]); ok {
+		t.Fatal("least recently used table should have been evicted")
+	}
+	if _, ok := cache.Lookup(&Q[0]); !ok {
+		t.Fatal("recently used table should not have been evicted")
+	}
+
+	// the returned tables are copies
+	lines[0][0].R0.SetOne()
+	if cached, _ := cache.Lookup(&Q[0]); cached == lines {
+		t.Fatal("modifying a returned table should not modify the cache")
+	}
+
+	cache.Remove(&Q[0])
+	if _, ok := cache.Lookup(&Q[0]); ok || cache.Len() != 1 {
+		t.Fatal("Remove failed")
+	}
+
+	// unbounded cache, used concurrently
+	cache = NewLineCache(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cache.Get(&Q[i%len(Q)])
+		}(i)
+	}
+	wg.Wait()
+	if cache.Len() != len(Q) {
+		t.Fatal("unbounded cache should hold all the tables")
+	}
+
+	// pairing with the cached lines
+	P := []G1Affine{g1GenAff, g1GenAff}
+	e1, _ := Pair(P, Q[:2])
+	e2, _ := PairFixedQ(P, cache.Lines(Q[:2]))
+	e3, _ := PairFixedQ(P, cache.Lines(Q[:2]))
+	if !e1.Equal(&e2) || !e1.Equal(&e3) {
+		t.Fatal("PairFixedQ with cached lines should match Pair")
+	}
+}
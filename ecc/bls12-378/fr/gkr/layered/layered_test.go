@@ -0,0 +1,228 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package layered
+
+import (
+	"crypto/sha256"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/test_vector_utils"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomCircuit returns a circuit with the given layer sizes (the first one being the number of inputs)
+// and random wiring
+func randomCircuit(rng *rand.Rand, sizes ...int) *Circuit {
+	c := &Circuit{NbInputs: sizes[0], Layers: make([]Layer, len(sizes)-1)}
+	for i := range c.Layers {
+		c.Layers[i] = make(Layer, sizes[i+1])
+		for j := range c.Layers[i] {
+			c.Layers[i][j] = Gate{
+				Type:  GateType(rng.Intn(2)),
+				Left:  rng.Intn(sizes[i]),
+				Right: rng.Intn(sizes[i]),
+			}
+		}
+	}
+	return c
+}
+
+func randomInputs(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestLayeredGKR(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewSource(1)) //#nosec G404 weak rng is fine here
+
+	for _, sizes := range [][]int{{1, 1}, {2, 1}, {4, 3, 2}, {5, 7, 16, 9, 3}, {33, 20, 64, 1}} {
+		c := randomCircuit(rng, sizes...)
+		inputs := randomInputs(sizes[0])
+		values, err := c.Evaluate(inputs)
+		assert.NoError(err)
+		outputs := values[len(values)-1]
+
+		proof, err := Prove(c, inputs, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.NoError(err)
+
+		err = Verify(c, inputs, outputs, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.NoError(err, "%v: proof rejected", sizes)
+
+		// wrong output
+		badOutputs := append([]fr.Element{}, outputs...)
+		var one fr.Element
+		one.SetOne()
+		badOutputs[0].Add(&badOutputs[0], &one)
+		err = Verify(c, inputs, badOutputs, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.Error(err, "%v: wrong output accepted", sizes)
+
+		// wrong input
+		badInputs := append([]fr.Element{}, inputs...)
+		badInputs[len(badInputs)-1].SetRandom()
+		err = Verify(c, badInputs, outputs, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.Error(err, "%v: wrong input accepted", sizes)
+
+		// wrong transcript; a circuit with a single input and output has no challenges
+		if len(ChallengeNames(c, "")) != 0 {
+			err = Verify(c, inputs, outputs, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(0, 1)))
+			assert.Error(err, "%v: proof accepted with a different transcript", sizes)
+		}
+	}
+}
+
+// TestLayeredGKRChangedInputs changes the inputs after proving, without changing their evaluations at the final
+// points of the sumcheck on the first layer. The proof must be rejected since the challenges depend on the inputs.
+func TestLayeredGKRChangedInputs(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewSource(2)) //#nosec G404 weak rng is fine here
+
+	c := randomCircuit(rng, 4, 8, 4)
+	inputs := randomInputs(4)
+	values, err := c.Evaluate(inputs)
+	assert.NoError(err)
+	outputs := values[len(values)-1]
+
+	names := ChallengeNames(c, "")
+	transcript := fiatshamir.NewTranscript(sha256.New(), names...)
+	proof, err := Prove(c, inputs, fiatshamir.WithTranscript(transcript, ""))
+	assert.NoError(err)
+	assert.NoError(Verify(c, inputs, outputs, proof, fiatshamir.WithTranscript(fiatshamir.NewTranscript(sha256.New(), names...), "")))
+
+	// the last 4 challenges are the points rₓ, r_y at which the inputs are evaluated
+	r := make([]fr.Element, 4)
+	for i := range r {
+		bytes, err := transcript.ComputeChallenge(names[len(names)-4+i])
+		assert.NoError(err)
+		r[i].SetBytes(bytes)
+	}
+	ex := eqTable(r[:2], nil, fr.Element{})
+	ey := eqTable(r[2:], nil, fr.Element{})
+
+	// δ on the first 3 inputs with δ(rₓ) = δ(r_y) = 0: the cross product of (eq(rₓ, i))ᵢ and (eq(r_y, i))ᵢ
+	var delta [3]fr.Element
+	var t_ fr.Element
+	for i := range delta {
+		j, k := (i+1)%3, (i+2)%3
+		delta[i].Mul(&ex[j], &ey[k])
+		t_.Mul(&ex[k], &ey[j])
+		delta[i].Sub(&delta[i], &t_)
+	}
+	badInputs := append([]fr.Element{}, inputs...)
+	for i := range delta {
+		badInputs[i].Add(&badInputs[i], &delta[i])
+	}
+	assert.NotEqual(inputs, badInputs)
+	in, badIn := polynomial.MultiLin(inputs), polynomial.MultiLin(badInputs)
+	assert.Equal(in.Evaluate(r[:2], nil), badIn.Evaluate(r[:2], nil))
+	assert.Equal(in.Evaluate(r[2:], nil), badIn.Evaluate(r[2:], nil))
+
+	err = Verify(c, badInputs, outputs, proof, fiatshamir.WithTranscript(fiatshamir.NewTranscript(sha256.New(), names...), ""))
+	assert.Error(err, "proof accepted for inputs changed after proving")
+
+	// the circuit is bound too
+	c.Layers[0][0].Type = 1 - c.Layers[0][0].Type
+	c.Layers[0][0].Left, c.Layers[0][0].Right = c.Layers[0][0].Right, c.Layers[0][0].Left
+	err = Verify(c, inputs, outputs, proof, fiatshamir.WithTranscript(fiatshamir.NewTranscript(sha256.New(), names...), ""))
+	assert.Error(err, "proof accepted for a different circuit")
+}
+
+func TestLayeredGKRCommitted(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewSource(3)) //#nosec G404 weak rng is fine here
+
+	c := randomCircuit(rng, 6, 8, 3)
+	inputs := randomInputs(6)
+	values, err := c.Evaluate(inputs)
+	assert.NoError(err)
+	outputs := values[len(values)-1]
+
+	// stands for a multilinear commitment to the inputs, opened by evaluating them in the clear
+	h := sha256.New()
+	for i := range inputs {
+		b := inputs[i].Bytes()
+		h.Write(b[:])
+	}
+	commitment := h.Sum(nil)
+	open := func(claim InputClaim) bool {
+		expected := padded(inputs).Evaluate(claim.Point, nil)
+		return expected.Equal(&claim.Evaluation)
+	}
+
+	_, _, err = ProveCommitted(c, inputs, fiatshamir.WithHash(sha256.New()))
+	assert.Error(err, "the commitment must be bound")
+
+	proof, proverClaims, err := ProveCommitted(c, inputs, fiatshamir.WithHash(sha256.New(), commitment))
+	assert.NoError(err)
+	claims, err := VerifyCommitted(c, outputs, proof, fiatshamir.WithHash(sha256.New(), commitment))
+	assert.NoError(err)
+	assert.Equal(proverClaims, claims)
+	for i := range claims {
+		assert.Len(claims[i].Point, 3)
+		assert.True(open(claims[i]), "claim %d on the inputs is wrong", i)
+	}
+
+	// the claims depend on the commitment
+	claims, err = VerifyCommitted(c, outputs, proof, fiatshamir.WithHash(sha256.New(), []byte("other commitment")))
+	if err == nil {
+		assert.False(open(claims[0]) && open(claims[1]), "claims on the inputs don't depend on the commitment")
+	}
+
+	// wrong output
+	badOutputs := append([]fr.Element{}, outputs...)
+	badOutputs[0].SetRandom()
+	claims, err = VerifyCommitted(c, badOutputs, proof, fiatshamir.WithHash(sha256.New(), commitment))
+	assert.False(err == nil && open(claims[0]) && open(claims[1]), "wrong output accepted")
+}
+
+func TestLayeredGKRMalformed(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Circuit{NbInputs: 2, Layers: []Layer{{{Type: Mul, Left: 0, Right: 2}}}}
+	_, err := Prove(c, randomInputs(2), fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err, "out of range input accepted")
+
+	c.Layers[0][0].Right = 1
+	inputs := randomInputs(2)
+	proof, err := Prove(c, inputs, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(err)
+	var output fr.Element
+	output.Mul(&inputs[0], &inputs[1])
+
+	proof[0].PartialSumPolys = proof[0].PartialSumPolys[1:]
+	err = Verify(c, inputs, []fr.Element{output}, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.Error(err)
+}
+
+func BenchmarkLayeredGKR(b *testing.B) {
+	rng := rand.New(rand.NewSource(3)) //#nosec G404 weak rng is fine here
+	const width = 1 << 14
+	c := randomCircuit(rng, width, width, width, width, width)
+	inputs := randomInputs(width)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Prove(c, inputs, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+const (
+	// newtonDivThreshold is the length of the quotient above which divisions use Newton iteration
+	newtonDivThreshold = 64
+	// subproductLeafLog is the log₂ of the number of points below which the subproduct tree
+	// is not descended further
+	subproductLeafLog = 4
+)
+
+// ErrDuplicatePoints is returned when interpolating on a set of points containing duplicates
+var ErrDuplicatePoints = errors.New("interpolation points must be distinct")
+
+// SubproductTree is the binary tree of the products ∏(X - xᵢ) over a set of points {xᵢ}.
+// The root is the vanishing polynomial of the set.
+// It is used for fast multipoint evaluation and interpolation, in O(n log² n).
+type SubproductTree struct {
+	points []fr.Element
+	// levels[k][j] = ∏(X - xᵢ) for i in [j·2ᵏ, (j+1)·2ᵏ)
+	levels [][]Polynomial
+}
+
+// NewSubproductTree builds the subproduct tree of points.
+func NewSubproductTree(points []fr.Element) *SubproductTree {
+	t := &SubproductTree{points: points}
+	if len(points) == 0 {
+		return t
+	}
+
+	leaves := make([]Polynomial, len(points))
+	for i := range points {
+		leaves[i] = make(Polynomial, 2)
+		leaves[i][0].Neg(&points[i])
+		leaves[i][1].SetOne()
+	}
+	t.levels = append(t.levels, leaves)
+
+	for prev := leaves; len(prev) > 1; {
+		next := make([]Polynomial, (len(prev)+1)/2)
+		parallel.Execute(len(next), func(start, end int) {
+			for j := start; j < end; j++ {
+				if 2*j+1 == len(prev) {
+					next[j] = prev[2*j]
+				} else {
+					next[j] = mul(prev[2*j], prev[2*j+1])
+				}
+			}
+		})
+		t.levels = append(t.levels, next)
+		prev = next
+	}
+
+	return t
+}
+
+// Vanishing returns the vanishing polynomial ∏(X - xᵢ) of the points of the tree.
+// The returned polynomial must not be modified.
+func (t *SubproductTree) Vanishing() Polynomial {
+	if len(t.levels) == 0 {
+		return Polynomial{fr.One()}
+	}
+	return t.levels[len(t.levels)-1][0]
+}
+
+// VanishingPolynomial returns ∏(X - xᵢ) over points.
+func VanishingPolynomial(points []fr.Element) Polynomial {
+	z := NewSubproductTree(points).Vanishing()
+	return z.Clone()
+}
+
+// Eval returns the evaluations of p at the points of the tree.
+func (t *SubproductTree) Eval(p Polynomial) []fr.Element {
+	res := make([]fr.Element, len(t.points))
+	if len(t.points) == 0 || len(p) == 0 {
+		return res
+	}
+	root := len(t.levels) - 1
+	if len(p) > len(t.points) {
+		_, p = DivRem(p, t.levels[root][0])
+	}
+	t.eval(p, root, 0, res)
+	return res
+}
+
+// eval sets res[i] = p(xᵢ) for the points xᵢ under the j-th node of the k-th level,
+// where deg p < the number of these points.
+func (t *SubproductTree) eval(p Polynomial, k, j int, res []fr.Element) {
+	start := j << k
+	end := min((j+1)<<k, len(t.points))
+	if k <= subproductLeafLog {
+		for i := start; i < end; i++ {
+			res[i] = p.Eval(&t.points[i])
+		}
+		return
+	}
+
+	left, right := 2*j, 2*j+1
+	if right == len(t.levels[k-1]) {
+		// no sibling, the node is its only child
+		t.eval(p, k-1, left, res)
+		return
+	}
+	_, pl := DivRem(p, t.levels[k-1][left])
+	_, pr := DivRem(p, t.levels[k-1][right])
+	if k > 8 {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			t.eval(pl, k-1, left, res)
+			wg.Done()
+		}()
+		t.eval(pr, k-1, right, res)
+		wg.Wait()
+		return
+	}
+	t.eval(pl, k-1, left, res)
+	t.eval(pr, k-1, right, res)
+}
+
+// Interpolate returns the unique polynomial of degree < len(values) taking values[i] at the i-th
+// point of the tree. It returns ErrDuplicatePoints if the points are not distinct.
+func (t *SubproductTree) Interpolate(values []fr.Element) (Polynomial, error) {
+	if len(values) != len(t.points) {
+		return nil, errors.New("number of values and points don't match")
+	}
+	if len(t.points) == 0 {
+		return Polynomial{}, nil
+	}
+
+	// Lagrange weights: 1/∏_{j≠i}(xᵢ - xⱼ) = 1/M'(xᵢ)
+	var dz Polynomial
+	dz.Derivative(t.Vanishing())
+	weights := t.Eval(dz)
+	for i := range weights {
+		if weights[i].IsZero() {
+			return nil, ErrDuplicatePoints
+		}
+	}
+	weights = fr.BatchInvert(weights)
+	for i := range weights {
+		weights[i].Mul(&weights[i], &values[i])
+	}
+
+	return t.linearCombination(weights, len(t.levels)-1, 0), nil
+}
+
+// linearCombination returns ∑ᵢ cᵢ·M(X)/(X - xᵢ) for the points xᵢ under the j-th node of the k-th level,
+// M being the polynomial of the node.
+func (t *SubproductTree) linearCombination(c []fr.Element, k, j int) Polynomial {
+	if k == 0 {
+		return Polynomial{c[j]}
+	}
+	left, right := 2*j, 2*j+1
+	if right == len(t.levels[k-1]) {
+		return t.linearCombination(c, k-1, left)
+	}
+
+	var l, r Polynomial
+	if k > 8 {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			l = t.linearCombination(c, k-1, left)
+			wg.Done()
+		}()
+		r = t.linearCombination(c, k-1, right)
+		wg.Wait()
+	} else {
+		l = t.linearCombination(c, k-1, left)
+		r = t.linearCombination(c, k-1, right)
+	}
+
+	l = mul(l, t.levels[k-1][right])
+	r = mul(r, t.levels[k-1][left])
+	l.Add(l, r)
+	return l
+}
+
+// EvalMultipoint returns the evaluations of p at points, using a subproduct tree.
+// To evaluate several polynomials on the same points, build the tree once with NewSubproductTree.
+func (p *Polynomial) EvalMultipoint(points []fr.Element) []fr.Element {
+	if len(points) <= 1<<subproductLeafLog {
+		res := make([]fr.Element, len(points))
+		for i := range points {
+			res[i] = p.Eval(&points[i])
+		}
+		return res
+	}
+	return NewSubproductTree(points).Eval(*p)
+}
+
+// Interpolate returns the unique polynomial of degree < len(points) such that p(points[i]) = values[i].
+// It returns ErrDuplicatePoints if the points are not distinct.
+func Interpolate(points, values []fr.Element) (Polynomial, error) {
+	return NewSubproductTree(points).Interpolate(values)
+}
+
+// DivRem returns the quotient and remainder of the euclidean division of a by b, such that
+// a = q·b + r with deg r < deg b. The remainder has exactly deg b coefficients
+// (leading ones may be zero). It panics if b is zero.
+//
+// When the quotient is large, it is computed with a Newton iteration on the reversed polynomials,
+// in O(M(n)) where M(n) is the cost of a multiplication.
+func DivRem(a, b Polynomial) (q, r Polynomial) {
+	b = b.trimmed()
+	if len(b) == 0 {
+		panic("division by zero polynomial")
+	}
+	a = a.trimmed()
+	m := len(b)
+
+	if len(a) < m {
+		r = make(Polynomial, m-1)
+		copy(r, a)
+		return Polynomial{}, r
+	}
+
+	k := len(a) - m + 1
+	if k <= newtonDivThreshold || m <= newtonDivThreshold {
+		return longDivision(a, b)
+	}
+
+	// rev(q) = rev(a) / rev(b) mod Xᵏ
+	ra := reversed(a[len(a)-k:])
+	inv := invSeries(reversed(b), k)
+	q = mul(ra, inv)[:k]
+	q = reversed(q)
+
+	bq := mul(b[:m-1], q)
+	r = make(Polynomial, m-1)
+	copy(r, a[:m-1])
+	for i := 0; i < m-1; i++ {
+		r[i].Sub(&r[i], &bq[i])
+	}
+	return q, r
+}
+
+// GCD returns the monic greatest common divisor of a and b, or an empty polynomial if both are zero.
+func GCD(a, b Polynomial) Polynomial {
+	a, b = a.trimmed(), b.trimmed()
+	for len(b) != 0 {
+		_, r := DivRem(a, b)
+		a, b = b, r.trimmed()
+	}
+	if len(a) == 0 {
+		return a
+	}
+	a = a.Clone()
+	var inv fr.Element
+	inv.Inverse(&a[len(a)-1])
+	a.ScaleInPlace(&inv)
+	return a
+}
+
+// longDivision is the schoolbook division, for trimmed a and b with len(a) ≥ len(b)
+func longDivision(a, b Polynomial) (q, r Polynomial) {
+	m := len(b)
+	r = a.Clone()
+	q = make(Polynomial, len(a)-m+1)
+
+	var inv, t fr.Element
+	inv.Inverse(&b[m-1])
+	for i := len(a) - 1; i >= m-1; i-- {
+		c := &q[i-m+1]
+		c.Mul(&r[i], &inv)
+		if c.IsZero() {
+			continue
+		}
+		for j := 0; j < m-1; j++ {
+			t.Mul(c, &b[j])
+			r[i-m+1+j].Sub(&r[i-m+1+j], &t)
+		}
+	}
+	return q, r[:m-1]
+}
+
+// invSeries returns g such that f·g = 1 mod Xᵏ; f[0] must be invertible
+func invSeries(f Polynomial, k int) Polynomial {
+	g := make(Polynomial, 1, k)
+	g[0].Inverse(&f[0])
+
+	var two fr.Element
+	two.SetUint64(2)
+	for l := 1; l < k; {
+		l = min(2*l, k)
+		// g ← g·(2 - f·g) mod Xˡ
+		t := mul(f[:min(len(f), l)], g)
+		t = t[:min(len(t), l)]
+		for i := range t {
+			t[i].Neg(&t[i])
+		}
+		t[0].Add(&t[0], &two)
+		g = mul(g, t)
+		g = g[:min(len(g), l)]
+	}
+	return g
+}
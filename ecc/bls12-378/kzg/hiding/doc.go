@@ -0,0 +1,26 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package hiding provides a hiding KZG commitment scheme (PolyCommit_Ped in
+// Kate, Zaverucha and Goldberg, ASIACRYPT 2010; as used in Marlin).
+//
+// The SRS has a second generator H, whose discrete logarithm in base G₁ is
+// unknown. A polynomial p is committed as [p(τ)]G₁ + [r̂(τ)]H for a random
+// blinding polynomial r̂. An opening at z reveals p(z), r̂(z) and the commitment
+// [q(τ)]G₁ + [q̂(τ)]H to the quotients q = (p-p(z))/(X-z) and q̂ = (r̂-r̂(z))/(X-z).
+// The commitment stays hiding as long as the number of openings does not exceed
+// the degree of r̂.
+package hiding
@@ -18,6 +18,8 @@ package bls12378
 
 import (
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"io"
 	"reflect"
@@ -49,6 +51,12 @@ const (
 // SizeOfGT represents the size in bytes that a GT element need in binary form
 const SizeOfGT = fptower.SizeOfGT
 
+// SizeOfGTCompressed represents the size in bytes that a GT element need in binary form, compressed on the torus
+const SizeOfGTCompressed = SizeOfGT / 2
+
+// To encode GT elements, the most significant bit of the first byte is set if the element is compressed on the torus
+const mCompressedGT byte = 0b1 << 7
+
 var (
 	ErrInvalidInfinityEncoding = errors.New("invalid infinity point encoding")
 	ErrInvalidEncoding         = errors.New("invalid point encoding")
@@ -81,7 +89,7 @@ func NewDecoder(r io.Reader, options ...func(*Decoder)) *Decoder {
 }
 
 // Decode reads the binary encoding of v from the stream
-// type must be *uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, *[]G1Affine or *[]G2Affine
+// type must be *uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, *GT, *[]G1Affine, *[]G2Affine or *[]GT
 func (dec *Decoder) Decode(v interface{}) (err error) {
 	rv := reflect.ValueOf(v)
 	if v == nil || rv.Kind() != reflect.Ptr || rv.IsNil() || !rv.Elem().CanSet() {
@@ -354,6 +362,37 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 			return errors.New("point decompression failed")
 		}
 
+		return nil
+	case *GT:
+		return dec.readGT(t, dec.subGroupCheck)
+	case *[]GT:
+		sliceLen, err = dec.readUint32()
+		if err != nil {
+			return
+		}
+		if len(*t) != int(sliceLen) || *t == nil {
+			*t = make([]GT, sliceLen)
+		}
+		for i := 0; i < len(*t); i++ {
+			if err = dec.readGT(&(*t)[i], false); err != nil {
+				return
+			}
+		}
+		if !dec.subGroupCheck {
+			return nil
+		}
+		var nbErrs uint64
+		parallel.Execute(len(*t), func(start, end int) {
+			for i := start; i < end; i++ {
+				if !(*t)[i].IsInSubGroup() {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		})
+		if nbErrs != 0 {
+			return errors.New("invalid GT element: subgroup check failed")
+		}
+
 		return nil
 	default:
 		n := binary.Size(t)
@@ -397,6 +436,37 @@ func (dec *Decoder) readUint64() (r uint64, err error) {
 	return
 }
 
+// readGT reads a GT element, compressed on the torus or not, and sets z to it
+func (dec *Decoder) readGT(z *GT, subGroupCheck bool) error {
+	var buf [SizeOfGT]byte
+
+	// we start by reading compressed size, if metadata tells us it is uncompressed, we read more.
+	read, err := io.ReadFull(dec.r, buf[:SizeOfGTCompressed])
+	dec.n += int64(read)
+	if err != nil {
+		return err
+	}
+	if buf[0]&mCompressedGT != 0 {
+		if err = setGTCompressedBytes(z, buf[:SizeOfGTCompressed]); err != nil {
+			return err
+		}
+	} else {
+		read, err = io.ReadFull(dec.r, buf[SizeOfGTCompressed:])
+		dec.n += int64(read)
+		if err != nil {
+			return err
+		}
+		if err = z.SetBytes(buf[:]); err != nil {
+			return err
+		}
+	}
+
+	if subGroupCheck && !z.IsInSubGroup() {
+		return errors.New("invalid GT element: subgroup check failed")
+	}
+	return nil
+}
+
 // isMaskInvalid returns true if the mask is invalid
 func isMaskInvalid(msb byte) bool {
 	mData := msb & mMask
@@ -426,7 +496,7 @@ func NewEncoder(w io.Writer, options ...func(*Encoder)) *Encoder {
 }
 
 // Encode writes the binary encoding of v to the stream
-// type must be uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, []G1Affine or []G2Affine
+// type must be uint64, *fr.Element, *fp.Element, *G1Affine, *G2Affine, *GT, []G1Affine, []G2Affine or []GT
 func (enc *Encoder) Encode(v interface{}) (err error) {
 	if enc.raw {
 		return enc.encodeRaw(v)
@@ -510,6 +580,8 @@ func (enc *Encoder) encode(v interface{}) (err error) {
 		written, err = enc.w.Write(buf[:])
 		enc.n += int64(written)
 		return
+	case *GT:
+		return enc.writeGT(t)
 	case fr.Vector:
 		written64, err = t.WriteTo(enc.w)
 		enc.n += written64
@@ -575,6 +647,20 @@ func (enc *Encoder) encode(v interface{}) (err error) {
 			}
 		}
 		return nil
+	case []GT:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		for i := 0; i < len(t); i++ {
+			if err = enc.writeGT(&t[i]); err != nil {
+				return
+			}
+		}
+		return nil
 	default:
 		n := binary.Size(t)
 		if n == -1 {
@@ -628,6 +714,8 @@ func (enc *Encoder) encodeRaw(v interface{}) (err error) {
 		written, err = enc.w.Write(buf[:])
 		enc.n += int64(written)
 		return
+	case *GT:
+		return enc.writeRawGT(t)
 	case fr.Vector:
 		written64, err = t.WriteTo(enc.w)
 		enc.n += written64
@@ -693,6 +781,20 @@ func (enc *Encoder) encodeRaw(v interface{}) (err error) {
 			}
 		}
 		return nil
+	case []GT:
+		// write slice length
+		err = binary.Write(enc.w, binary.BigEndian, uint32(len(t)))
+		if err != nil {
+			return
+		}
+		enc.n += 4
+
+		for i := 0; i < len(t); i++ {
+			if err = enc.writeRawGT(&t[i]); err != nil {
+				return
+			}
+		}
+		return nil
 	default:
 		n := binary.Size(t)
 		if n == -1 {
@@ -749,6 +851,134 @@ func (enc *Encoder) writeUint32(a uint32) error {
 	return err
 }
 
+func (enc *Encoder) writeGT(z *GT) error {
+	buf, err := gtCompressedBytes(z)
+	if err != nil {
+		return err
+	}
+	written, err := enc.w.Write(buf[:])
+	enc.n += int64(written)
+	return err
+}
+
+func (enc *Encoder) writeRawGT(z *GT) error {
+	buf := z.Bytes()
+	written, err := enc.w.Write(buf[:])
+	enc.n += int64(written)
+	return err
+}
+
+// gtCompressedBytes returns the binary representation of z compressed on the torus
+// (see GT.CompressTorus), with the most significant bit set.
+//
+// The identity is encoded as 0, which is the compression of -1 ∉ GT.
+func gtCompressedBytes(z *GT) (res [SizeOfGTCompressed]byte, err error) {
+	var y GT
+	if !z.IsOne() {
+		if y.C0, err = z.CompressTorus(); err != nil {
+			return
+		}
+	}
+	// y.C0 holds the compressed element, we serialize it as half of a GT element
+	b := y.Bytes()
+	copy(res[:], b[SizeOfGTCompressed:])
+	res[0] |= mCompressedGT
+	return
+}
+
+// setGTCompressedBytes sets z from its binary representation compressed on the torus,
+// as returned by gtCompressedBytes.
+func setGTCompressedBytes(z *GT, buf []byte) error {
+	if len(buf) != SizeOfGTCompressed || buf[0]&mCompressedGT == 0 {
+		return ErrInvalidEncoding
+	}
+	var b [SizeOfGT]byte
+	copy(b[SizeOfGTCompressed:], buf)
+	b[SizeOfGTCompressed] &^= mCompressedGT
+
+	var y GT
+	if err := y.SetBytes(b[:]); err != nil {
+		return err
+	}
+	if y.C0.IsZero() {
+		z.SetOne()
+		return nil
+	}
+	*z = y.C0.DecompressTorus()
+	return nil
+}
+
+// SizeOfLineTable represents the size in bytes that a LineTable needs in binary form.
+// Each line evaluation holds two fptower.E2 coordinates, that is 4 fp.Element.
+const SizeOfLineTable = len(LineTable{}) * len(LineTable{}[0]) * 4 * fp.Bytes
+
+// coordinates returns pointers to the base field coordinates of the line evaluation, in serialization order
+func (l *LineEvaluationAff) coordinates() [4]*fp.Element {
+	return [4]*fp.Element{&l.R0.A1, &l.R0.A0, &l.R1.A1, &l.R1.A0}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It returns the SizeOfLineTable bytes of the big-endian coordinates of the lines.
+func (t *LineTable) MarshalBinary() ([]byte, error) {
+	res := make([]byte, SizeOfLineTable)
+	offset := 0
+	for i := range t {
+		for j := range t[i] {
+			for _, c := range t[i][j].coordinates() {
+				fp.BigEndian.PutElement((*[fp.Bytes]byte)(res[offset:offset+fp.Bytes]), *c)
+				offset += fp.Bytes
+			}
+		}
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error if buf is not of size SizeOfLineTable or holds non canonical field elements,
+// in which case t is left unchanged.
+//
+// The lines are not checked against a G2 point; the table must come from a trusted source.
+func (t *LineTable) UnmarshalBinary(buf []byte) error {
+	if len(buf) != SizeOfLineTable {
+		return ErrInvalidEncoding
+	}
+	var res LineTable
+	offset := 0
+	for i := range res {
+		for j := range res[i] {
+			for _, c := range res[i][j].coordinates() {
+				if err := c.SetBytesCanonical(buf[offset : offset+fp.Bytes]); err != nil {
+					return err
+				}
+				offset += fp.Bytes
+			}
+		}
+	}
+	*t = res
+	return nil
+}
+
+// WriteTo writes the binary encoding of the lines to w, see MarshalBinary.
+//
+// It implements io.WriterTo.
+func (t *LineTable) WriteTo(w io.Writer) (int64, error) {
+	buf, _ := t.MarshalBinary()
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// ReadFrom reads SizeOfLineTable bytes from r and decodes them into t, see UnmarshalBinary.
+//
+// It implements io.ReaderFrom.
+func (t *LineTable) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, SizeOfLineTable)
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return int64(n), err
+	}
+	return int64(n), t.UnmarshalBinary(buf)
+}
+
 // SizeOfG1AffineCompressed represents the size in bytes that a G1Affine need in binary form, compressed
 const SizeOfG1AffineCompressed = 48
 
@@ -1010,6 +1240,78 @@ func (p *G1Affine) unsafeSetCompressedBytes(buf []byte) (isInfinity bool, err er
 	return isInfinity, nil
 }
 
+// g1AffineJSON is the JSON representation of a G1Affine, see G1Affine.MarshalJSON
+type g1AffineJSON struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// MarshalJSON returns the JSON encoding of p
+//
+//	{"x": "0x…", "y": "0x…"}
+//
+// where each coordinate is the canonical hex encoding of a fp.Element (see fp.Element.MarshalText).
+// The point at infinity is encoded with zero coordinates.
+func (p *G1Affine) MarshalJSON() ([]byte, error) {
+	var v g1AffineJSON
+	var b []byte
+	b, _ = p.X.MarshalText()
+	v.X = string(b)
+	b, _ = p.Y.MarshalText()
+	v.Y = string(b)
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON sets p from its JSON encoding, see MarshalJSON.
+// Coordinates must be canonical, and the point must be on the curve and in the correct subgroup.
+func (p *G1Affine) UnmarshalJSON(data []byte) error {
+	var v g1AffineJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var q G1Affine
+	if err := q.X.UnmarshalText([]byte(v.X)); err != nil {
+		return err
+	}
+	if err := q.Y.UnmarshalText([]byte(v.Y)); err != nil {
+		return err
+	}
+	if !q.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	p.Set(&q)
+	return nil
+}
+
+// MarshalText returns the hex encoding of the compressed point, prefixed with "0x", see Bytes()
+func (p *G1Affine) MarshalText() ([]byte, error) {
+	b := p.Bytes()
+	res := make([]byte, 2+hex.EncodedLen(len(b)))
+	res[0], res[1] = '0', 'x'
+	hex.Encode(res[2:], b[:])
+	return res, nil
+}
+
+// UnmarshalText sets p from the hex encoding of a compressed or uncompressed point,
+// with an optional "0x" prefix, see SetBytes()
+func (p *G1Affine) UnmarshalText(text []byte) error {
+	if len(text) >= 2 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+	}
+	buf := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(buf, text); err != nil {
+		return err
+	}
+	n, err := p.SetBytes(buf)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) {
+		return ErrInvalidEncoding
+	}
+	return nil
+}
+
 // SizeOfG2AffineCompressed represents the size in bytes that a G2Affine need in binary form, compressed
 const SizeOfG2AffineCompressed = 48 * 2
 
@@ -1295,3 +1597,85 @@ func (p *G2Affine) unsafeSetCompressedBytes(buf []byte) (isInfinity bool, err er
 	// recomputing Y will be done asynchronously
 	return isInfinity, nil
 }
+
+// g2AffineJSON is the JSON representation of a G2Affine, see G2Affine.MarshalJSON
+type g2AffineJSON struct {
+	X [2]string `json:"x"`
+	Y [2]string `json:"y"`
+}
+
+// MarshalJSON returns the JSON encoding of p
+//
+//	{"x": ["0x…(A0)", "0x…(A1)"], "y": ["0x…(A0)", "0x…(A1)"]}
+//
+// where each coordinate is the canonical hex encoding of a fp.Element (see fp.Element.MarshalText).
+// The point at infinity is encoded with zero coordinates.
+func (p *G2Affine) MarshalJSON() ([]byte, error) {
+	var v g2AffineJSON
+	var b []byte
+	b, _ = p.X.A0.MarshalText()
+	v.X[0] = string(b)
+	b, _ = p.Y.A0.MarshalText()
+	v.Y[0] = string(b)
+	b, _ = p.X.A1.MarshalText()
+	v.X[1] = string(b)
+	b, _ = p.Y.A1.MarshalText()
+	v.Y[1] = string(b)
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON sets p from its JSON encoding, see MarshalJSON.
+// Coordinates must be canonical, and the point must be on the curve and in the correct subgroup.
+func (p *G2Affine) UnmarshalJSON(data []byte) error {
+	var v g2AffineJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var q G2Affine
+	if err := q.X.A0.UnmarshalText([]byte(v.X[0])); err != nil {
+		return err
+	}
+	if err := q.Y.A0.UnmarshalText([]byte(v.Y[0])); err != nil {
+		return err
+	}
+	if err := q.X.A1.UnmarshalText([]byte(v.X[1])); err != nil {
+		return err
+	}
+	if err := q.Y.A1.UnmarshalText([]byte(v.Y[1])); err != nil {
+		return err
+	}
+	if !q.IsInSubGroup() {
+		return errors.New("invalid point: subgroup check failed")
+	}
+	p.Set(&q)
+	return nil
+}
+
+// MarshalText returns the hex encoding of the compressed point, prefixed with "0x", see Bytes()
+func (p *G2Affine) MarshalText() ([]byte, error) {
+	b := p.Bytes()
+	res := make([]byte, 2+hex.EncodedLen(len(b)))
+	res[0], res[1] = '0', 'x'
+	hex.Encode(res[2:], b[:])
+	return res, nil
+}
+
+// UnmarshalText sets p from the hex encoding of a compressed or uncompressed point,
+// with an optional "0x" prefix, see SetBytes()
+func (p *G2Affine) UnmarshalText(text []byte) error {
+	if len(text) >= 2 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+	}
+	buf := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(buf, text); err != nil {
+		return err
+	}
+	n, err := p.SetBytes(buf)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) {
+		return ErrInvalidEncoding
+	}
+	return nil
+}
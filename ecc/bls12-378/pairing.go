@@ -377,8 +377,141 @@ func PairingCheckFixedQ(P []G1Affine, lines [][2][len(LoopCounter) - 1]LineEvalu
 	return f.Equal(&one), nil
 }
 
+// PairMixedQ calculates the reduced pairing for a set of points
+// ∏ᵢ e(Pᵢ, Qᵢ) · ∏ⱼ e(P'ⱼ, Q'ⱼ) where Q' are fixed points in G2 given by their
+// precomputed lines.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairMixedQ(P []G1Affine, Q []G2Affine, PFixed []G1Affine, lines [][2][len(LoopCounter) - 1]LineEvaluationAff) (GT, error) {
+	f, err := MillerLoopMixedQ(P, Q, PFixed, lines)
+	if err != nil {
+		return GT{}, err
+	}
+	return FinalExponentiation(&f), nil
+}
+
+// PairingCheckMixedQ calculates the reduced pairing for a set of points and returns True if the result is One
+// ∏ᵢ e(Pᵢ, Qᵢ) · ∏ⱼ e(P'ⱼ, Q'ⱼ) =? 1 where Q' are fixed points in G2.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingCheckMixedQ(P []G1Affine, Q []G2Affine, PFixed []G1Affine, lines [][2][len(LoopCounter) - 1]LineEvaluationAff) (bool, error) {
+	f, err := PairMixedQ(P, Q, PFixed, lines)
+	if err != nil {
+		return false, err
+	}
+	var one GT
+	one.SetOne()
+	return f.Equal(&one), nil
+}
+
+// MillerLoopMixedQ computes the multi-Miller loop
+// ∏ᵢ MillerLoop(Pᵢ, Qᵢ) · ∏ⱼ MillerLoopFixedQ(P'ⱼ, Q'ⱼ)
+// where Q' are fixed points in G2 given by their precomputed lines.
+//
+// The variable and fixed pairs share the squarings of a single loop, so that
+// e.g. e(A,B)·e(C,[δ])·e(D,[γ]) costs one loop and one final exponentiation.
+// Unlike MillerLoopFixedQ, the precomputed lines are not modified.
+func MillerLoopMixedQ(P []G1Affine, Q []G2Affine, PFixed []G1Affine, lines [][2][len(LoopCounter) - 1]LineEvaluationAff) (GT, error) {
+	// check input size match
+	if len(P) != len(Q) || len(PFixed) != len(lines) || len(P)+len(PFixed) == 0 {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	// filter infinity points of the variable pairs
+	// (infinity points of the fixed pairs are handled as in MillerLoopFixedQ)
+	p := make([]G1Affine, 0, len(P))
+	q := make([]G2Affine, 0, len(Q))
+	for k := 0; k < len(P); k++ {
+		if P[k].IsInfinity() || Q[k].IsInfinity() {
+			continue
+		}
+		p = append(p, P[k])
+		q = append(q, Q[k])
+	}
+	n := len(p)
+	m := len(PFixed)
+
+	// projective points for Q
+	qProj := make([]g2Proj, n)
+	for k := 0; k < n; k++ {
+		qProj[k].FromAffine(&q[k])
+	}
+
+	// precomputations for the fixed pairs
+	yInv := make([]fp.Element, m)
+	xNegOverY := make([]fp.Element, m)
+	for k := 0; k < m; k++ {
+		yInv[k].Set(&PFixed[k].Y)
+	}
+	yInv = fp.BatchInvert(yInv)
+	for k := 0; k < m; k++ {
+		xNegOverY[k].Mul(&PFixed[k].X, &yInv[k]).
+			Neg(&xNegOverY[k])
+	}
+
+	var result GT
+	result.SetOne()
+	var l1, l2 lineEvaluation
+	var lf1, lf2 LineEvaluationAff
+	var prodLines [5]E2
+
+	for i := len(LoopCounter) - 2; i >= 0; i-- {
+		// mutualize the square among all the Miller loops
+		// (∏ᵢfᵢ)²
+		result.Square(&result)
+
+		for k := 0; k < n; k++ {
+			// qProj[k] ← 2qProj[k] and l1 the tangent ℓ passing 2qProj[k]
+			qProj[k].doubleStep(&l1)
+			// line evaluation at P[k]
+			l1.r1.MulByElement(&l1.r1, &p[k].X)
+			l1.r2.MulByElement(&l1.r2, &p[k].Y)
+			if LoopCounter[i] == 0 {
+				// ℓ × res
+				result.MulBy014(&l1.r0, &l1.r1, &l1.r2)
+				continue
+			}
+			// qProj[k] ← qProj[k]+Q[k] and
+			// l2 the line ℓ passing qProj[k] and Q[k]
+			qProj[k].addMixedStep(&l2, &q[k])
+			// line evaluation at P[k]
+			l2.r1.MulByElement(&l2.r1, &p[k].X)
+			l2.r2.MulByElement(&l2.r2, &p[k].Y)
+			// ℓ × ℓ
+			prodLines = fptower.Mul014By014(&l1.r0, &l1.r1, &l1.r2, &l2.r0, &l2.r1, &l2.r2)
+			// (ℓ × ℓ) × res
+			result.MulBy01245(&prodLines)
+		}
+
+		for k := 0; k < m; k++ {
+			// line evaluation at PFixed[k]
+			lf1.R1.MulByElement(&lines[k][0][i].R1, &yInv[k])
+			lf1.R0.MulByElement(&lines[k][0][i].R0, &xNegOverY[k])
+			if LoopCounter[i] == 0 {
+				// ℓ × res
+				result.MulBy01(&lf1.R1, &lf1.R0)
+				continue
+			}
+			// line evaluation at PFixed[k]
+			lf2.R1.MulByElement(&lines[k][1][i].R1, &yInv[k])
+			lf2.R0.MulByElement(&lines[k][1][i].R0, &xNegOverY[k])
+			// ℓ × ℓ
+			prodLines = fptower.Mul01By01(&lf1.R1, &lf1.R0, &lf2.R1, &lf2.R0)
+			// (ℓ × ℓ) × res
+			result.MulBy01245(&prodLines)
+		}
+	}
+
+	return result, nil
+}
+
+// LineTable holds the lines of the fixed-argument Miller loop for a point Q in G2.
+// LineTable[0] holds the tangent lines and LineTable[1] the lines of the additions.
+// See PrecomputeLines, LineCache and LineTable.WriteTo.
+type LineTable [2][len(LoopCounter) - 1]LineEvaluationAff
+
 // PrecomputeLines precomputes the lines for the fixed-argument Miller loop
-func PrecomputeLines(Q G2Affine) (PrecomputedLines [2][len(LoopCounter) - 1]LineEvaluationAff) {
+func PrecomputeLines(Q G2Affine) (PrecomputedLines LineTable) {
 	var accQ G2Affine
 	accQ.Set(&Q)
 
@@ -0,0 +1,121 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LineCache caches the precomputed lines of fixed arguments of the pairing, keyed by
+// their G2 point. Long-running verifiers can use it to share the LineTable of the G2
+// points of their verification keys among calls to MillerLoopFixedQ or MillerLoopMixedQ.
+//
+// When the cache is full, the least recently used table is evicted.
+// It is safe for concurrent use.
+type LineCache struct {
+	lock     sync.Mutex
+	capacity int
+	entries  map[G2Affine]*list.Element
+	lru      *list.List // most recently used at the front
+}
+
+type lineCacheEntry struct {
+	q     G2Affine
+	lines LineTable
+}
+
+// NewLineCache returns a LineCache holding at most capacity tables.
+// If capacity <= 0, tables are never evicted.
+func NewLineCache(capacity int) *LineCache {
+	return &LineCache{
+		capacity: capacity,
+		entries:  make(map[G2Affine]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Get returns the precomputed lines of Q, computing them with PrecomputeLines
+// and storing them in the cache if they are not present.
+//
+// The returned table is a copy that callers may modify (MillerLoopFixedQ does).
+func (c *LineCache) Get(Q *G2Affine) LineTable {
+	if lines, ok := c.Lookup(Q); ok {
+		return lines
+	}
+	// computed outside of the lock, concurrent misses on the same point may compute it twice.
+	lines := PrecomputeLines(*Q)
+	c.Add(Q, &lines)
+	return lines
+}
+
+// Lookup returns the precomputed lines of Q if they are in the cache.
+func (c *LineCache) Lookup(Q *G2Affine) (LineTable, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.entries[*Q]
+	if !ok {
+		return LineTable{}, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*lineCacheEntry).lines, true
+}
+
+// Add stores the precomputed lines of Q in the cache, for instance after reading
+// them with LineTable.ReadFrom. lines must be PrecomputeLines(Q).
+func (c *LineCache) Add(Q *G2Affine, lines *LineTable) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.entries[*Q]; ok {
+		e.Value.(*lineCacheEntry).lines = *lines
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[*Q] = c.lru.PushFront(&lineCacheEntry{q: *Q, lines: *lines})
+	if c.capacity > 0 && c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lineCacheEntry).q)
+	}
+}
+
+// Remove removes the precomputed lines of Q from the cache, if present.
+func (c *LineCache) Remove(Q *G2Affine) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.entries[*Q]; ok {
+		c.lru.Remove(e)
+		delete(c.entries, *Q)
+	}
+}
+
+// Len returns the number of tables in the cache.
+func (c *LineCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// Lines returns the precomputed lines of the points Q, as expected by
+// MillerLoopFixedQ, PairFixedQ and MillerLoopMixedQ. See Get.
+func (c *LineCache) Lines(Q []G2Affine) [][2][len(LoopCounter) - 1]LineEvaluationAff {
+	res := make([][2][len(LoopCounter) - 1]LineEvaluationAff, len(Q))
+	for i := range Q {
+		res[i] = c.Get(&Q[i])
+	}
+	return res
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	assert := require.New(t)
+
+	acc, err := New(big.NewInt(42))
+	assert.NoError(err)
+	pk := acc.PublicKey()
+
+	elements := randomElements(6)
+	_, err = acc.Add(elements[:4]...)
+	assert.NoError(err)
+	_, err = acc.Add(elements[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+
+	// invalid batches leave the accumulator unchanged
+	value := acc.Value()
+	_, err = acc.Add(elements[4], elements[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+	_, err = acc.Add(elements[4], elements[4])
+	assert.ErrorIs(err, ErrAlreadyMember)
+	_, err = acc.Delete(elements[1], elements[4])
+	assert.ErrorIs(err, ErrNotMember)
+	_, err = acc.Delete(elements[1], elements[1])
+	assert.ErrorIs(err, ErrNotMember)
+	actual := acc.Value()
+	assert.True(value.Equal(&actual))
+	assert.False(acc.Contains(&elements[4]))
+	assert.True(acc.Contains(&elements[1]))
+
+	// membership
+	witnesses := make([]MembershipWitness, 4)
+	for i := range witnesses {
+		witnesses[i], err = acc.MembershipWitness(elements[i])
+		assert.NoError(err)
+		assert.NoError(pk.VerifyMembership(acc.Value(), witnesses[i]))
+	}
+	_, err = acc.MembershipWitness(elements[4])
+	assert.ErrorIs(err, ErrNotMember)
+
+	forged := witnesses[0]
+	forged.Element = elements[4]
+	assert.ErrorIs(pk.VerifyMembership(acc.Value(), forged), ErrVerifyMembership)
+
+	// non-membership
+	nonMember, err := acc.NonMembershipWitness(elements[4])
+	assert.NoError(err)
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), nonMember))
+	_, err = acc.NonMembershipWitness(elements[0])
+	assert.ErrorIs(err, ErrAlreadyMember)
+
+	forgedNonMember := nonMember
+	forgedNonMember.Element = elements[0]
+	assert.ErrorIs(pk.VerifyNonMembership(acc.Value(), forgedNonMember), ErrVerifyNonMembership)
+	forgedNonMember = nonMember
+	forgedNonMember.D.SetZero()
+	assert.ErrorIs(pk.VerifyNonMembership(acc.Value(), forgedNonMember), ErrVerifyNonMembership)
+
+	// deletion
+	_, err = acc.Delete(elements[4])
+	assert.ErrorIs(err, ErrNotMember)
+	_, err = acc.Delete(elements[3])
+	assert.NoError(err)
+	assert.ErrorIs(pk.VerifyMembership(acc.Value(), witnesses[3]), ErrVerifyMembership)
+	nonMember, err = acc.NonMembershipWitness(elements[3])
+	assert.NoError(err)
+	assert.NoError(pk.VerifyNonMembership(acc.Value(), nonMember))
+
+	// the accumulator only depends on the set
+	other, err := New(big.NewInt(42))
+	assert.NoError(err)
+	_, err = other.Add(elements[2], elements[0], elements[1])
+	assert.NoError(err)
+	expected := other.Value()
+	actual = acc.Value()
+	assert.True(expected.Equal(&actual))
+}
+
+func TestWitnessUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	acc, err := New(big.NewInt(1234))
+	assert.NoError(err)
+	pk := acc.PublicKey()
+
+	elements := randomElements(10)
+	_, err = acc.Add(elements[:5]...)
+	assert.NoError(err)
+
+	witnesses := make([]MembershipWitness, 3)
+	for i := range witnesses {
+		witnesses[i], err = acc.MembershipWitness(elements[i])
+		assert.NoError(err)
+	}
+	nonMembers := make([]NonMembershipWitness, 2)
+	for i := range nonMembers {
+		nonMembers[i], err = acc.NonMembershipWitness(elements[8+i])
+		assert.NoError(err)
+	}
+
+	// additions and deletions, interleaved
+	var updates []Update
+	u, err := acc.Add(elements[5:8]...)
+	assert.NoError(err)
+	for i := range u {
+		// the values of a batch are the ones of sequential additions
+		previous := u[i].Previous
+		assert.NoError(pk.VerifyMembership(u[i].Value, MembershipWitness{Element: u[i].Element, W: previous}))
+	}
+	updates = append(updates, u...)
+	u, err = acc.Delete(elements[3], elements[6])
+	assert.NoError(err)
+	updates = append(updates, u...)
+	u, err = acc.Add(elements[3])
+	assert.NoError(err)
+	updates = append(updates, u...)
+	u, err = acc.Delete(elements[4])
+	assert.NoError(err)
+	updates = append(updates, u...)
+
+	assert.NoError(UpdateMembershipWitnesses(witnesses, updates...))
+	for i := range witnesses {
+		assert.NoError(pk.VerifyMembership(acc.Value(), witnesses[i]))
+		expected, err := acc.MembershipWitness(elements[i])
+		assert.NoError(err)
+		assert.True(expected.W.Equal(&witnesses[i].W))
+	}
+	single := nonMembers[0]
+	assert.NoError(UpdateNonMembershipWitnesses(nonMembers, updates...))
+	for i := range nonMembers {
+		assert.NoError(pk.VerifyNonMembership(acc.Value(), nonMembers[i]))
+		expected, err := acc.NonMembershipWitness(elements[8+i])
+		assert.NoError(err)
+		assert.True(expected.W.Equal(&nonMembers[i].W))
+		assert.True(expected.D.Equal(&nonMembers[i].D))
+	}
+
+	// one update at a time
+	for i := range updates {
+		assert.NoError(single.Update(updates[i]))
+	}
+	assert.True(single.W.Equal(&nonMembers[0].W))
+
+	// a witness can't be updated with the addition or deletion of its own element
+	u, err = acc.Delete(elements[0])
+	assert.NoError(err)
+	w := witnesses[0]
+	assert.ErrorIs(UpdateMembershipWitnesses(witnesses, u...), ErrInvalidUpdate)
+	assert.True(w.W.Equal(&witnesses[0].W), "a witness must be left unchanged on error")
+	u, err = acc.Add(elements[8])
+	assert.NoError(err)
+	assert.ErrorIs(nonMembers[0].Update(u...), ErrInvalidUpdate)
+}
+
+func BenchmarkWitnessUpdate(b *testing.B) {
+	const nbUpdates = 64
+	acc, _ := New(big.NewInt(1234))
+	elements := randomElements(nbUpdates + 1)
+	_, _ = acc.Add(elements[0])
+	w, _ := acc.MembershipWitness(elements[0])
+	updates, _ := acc.Add(elements[1:]...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		witness := w
+		_ = witness.Update(updates...)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+// sBox is a Poseidon-like partial round: x⁵ + y
+func sBox(x ...fr.Element) fr.Element {
+	var res fr.Element
+	res.Square(&x[0]).Square(&res).Mul(&res, &x[0]).Add(&res, &x[1])
+	return res
+}
+
+// removeTestGates unregisters the gates whose name starts with "test-"
+func removeTestGates() {
+	gatesLock.Lock()
+	defer gatesLock.Unlock()
+	for name := range Gates {
+		if strings.HasPrefix(name, "test-") {
+			delete(Gates, name)
+		}
+	}
+}
+
+func TestRegisterGate(t *testing.T) {
+	assert := assert.New(t)
+	t.Cleanup(removeTestGates)
+
+	assert.NoError(RegisterGate("test-sbox", sBox, 2))
+	g := GetGate("test-sbox")
+	assert.NotNil(g)
+	assert.Equal(5, g.Degree())
+
+	x := []fr.Element{two, three}
+	expected := sBox(x...)
+	actual := g.Evaluate(x...)
+	assert.True(expected.Equal(&actual))
+
+	// names are unique
+	assert.Error(RegisterGate("test-sbox", sBox, 2))
+
+	// declared degree
+	assert.NoError(RegisterGate("test-sbox-deg", sBox, 2, WithDegree(5)))
+	assert.Error(RegisterGate("test-sbox-wrong-deg", sBox, 2, WithDegree(4)))
+	assert.NoError(RegisterGate("test-sbox-unverified", sBox, 2, WithUnverifiedDegree(4)))
+	assert.Equal(4, GetGate("test-sbox-unverified").Degree())
+
+	// x·y·z has total degree 3
+	assert.NoError(RegisterGate("test-mul3", func(x ...fr.Element) fr.Element {
+		var res fr.Element
+		res.Mul(&x[0], &x[1]).Mul(&res, &x[2])
+		return res
+	}, 3))
+	assert.Equal(3, GetGate("test-mul3").Degree())
+
+	// 1/x is not a polynomial
+	inv := func(x ...fr.Element) fr.Element {
+		var res fr.Element
+		res.Inverse(&x[0])
+		return res
+	}
+	assert.Error(RegisterGate("test-inv", inv, 1))
+	assert.Nil(GetGate("test-inv"))
+
+	// x⁵ exceeds a max degree of 4
+	assert.Error(RegisterGate("test-sbox-max", sBox, 2, WithMaxDegree(4)))
+
+	assert.Error(RegisterGate("test-no-input", sBox, 0))
+}
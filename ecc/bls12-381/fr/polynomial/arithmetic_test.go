@@ -0,0 +1,176 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolynomialMul(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{1, 1}, {5, 17}, {40, 40}, {100, 33}, {200, 150}, {1000, 129}, {300, 300}} {
+		a := randomPolynomial(sizes[0])
+		b := randomPolynomial(sizes[1])
+		expected := mulSchoolbook(a, b)
+
+		var p Polynomial
+		p.Mul(a, b)
+		assert.True(p.Equal(expected), "%d×%d: Mul mismatch", sizes[0], sizes[1])
+
+		p = mulKaratsuba(a, b)
+		assert.True(p.Equal(expected), "%d×%d: karatsuba mismatch", sizes[0], sizes[1])
+
+		p = mulFFT(a, b)
+		assert.True(p.Equal(expected), "%d×%d: fft mismatch", sizes[0], sizes[1])
+	}
+
+	// aliasing
+	a := randomPolynomial(50)
+	expected := mulSchoolbook(a, a)
+	a.Mul(a, a)
+	assert.True(a.Equal(expected))
+}
+
+func TestPolynomialMulLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	// the product domain is larger than the largest cached one
+	const n = 1<<(maxCachedDomainLog-1) + 1
+	a := randomPolynomial(n)
+	b := randomPolynomial(n)
+	var p Polynomial
+	p.Mul(a, b)
+
+	var z, expected fr.Element
+	z.SetRandom()
+	ea, eb := a.Eval(&z), b.Eval(&z)
+	expected.Mul(&ea, &eb)
+	actual := p.Eval(&z)
+	assert.True(expected.Equal(&actual))
+
+	assert.Same(getDomain(100), getDomain(128), "small domains must be cached")
+	assert.NotSame(getDomain(1<<(maxCachedDomainLog+1)), getDomain(1<<(maxCachedDomainLog+1)), "large domains must not be cached")
+}
+
+func TestPolynomialDiv(t *testing.T) {
+	assert := assert.New(t)
+
+	a := randomPolynomial(300)
+	b := randomPolynomial(120)
+	var p Polynomial
+	p.Mul(a, b).Div(p, b)
+	assert.True(p.Equal(a))
+}
+
+func TestPolynomialDivideByVanishing(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 16
+	z := make(Polynomial, n+1)
+	z[0].SetOne().Neg(&z[0])
+	z[n].SetOne()
+
+	for _, size := range []int{1, 10, 40} {
+		q := randomPolynomial(size)
+		var p Polynomial
+		p.Mul(q, z)
+		_, err := p.DivideByVanishing(p, n)
+		assert.NoError(err)
+		assert.True(p.Equal(q), "size %d: wrong quotient", size)
+
+		// add a remainder
+		p.Mul(q, z)
+		r := randomPolynomial(n)
+		p.Add(p, r)
+		_, err = p.DivideByVanishing(p, n)
+		assert.ErrorIs(err, ErrNotDivisible)
+		assert.True(p.Equal(q), "size %d: wrong quotient with remainder", size)
+	}
+
+	var p Polynomial
+	_, err := p.DivideByVanishing(make(Polynomial, 5), n)
+	assert.NoError(err)
+	assert.Equal(0, len(p))
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+	assert := assert.New(t)
+
+	// (X - x)ⁿ' = n·(X - x)ⁿ⁻¹
+	const n = 10
+	x := randomPoints(1)[0]
+	l := Polynomial{{}, fr.One()}
+	l[0].Neg(&x)
+	p := Polynomial{fr.One()}
+	for i := 0; i < n-1; i++ {
+		p.Mul(p, l)
+	}
+	var c fr.Element
+	c.SetUint64(n)
+	var expected Polynomial
+	expected.Scale(&c, p)
+
+	p.Mul(p, l).Derivative(p)
+	assert.True(p.Equal(expected))
+
+	assert.Equal(0, len(*p.Derivative(Polynomial{c})))
+}
+
+func TestPolynomialCompose(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{1, 5}, {2, 5}, {13, 1}, {13, 7}, {64, 10}, {100, 50}} {
+		f := randomPolynomial(sizes[0])
+		g := randomPolynomial(sizes[1])
+		var p Polynomial
+		p.Compose(f, g)
+		assert.Equal((sizes[0]-1)*(sizes[1]-1)+1, len(p), "%d∘%d: wrong degree", sizes[0], sizes[1])
+
+		x := randomPoints(1)[0]
+		gx := g.Eval(&x)
+		expected := f.Eval(&gx)
+		actual := p.Eval(&x)
+		assert.True(actual.Equal(&expected), "%d∘%d: wrong composition", sizes[0], sizes[1])
+	}
+}
+
+func BenchmarkPolynomialMul(b *testing.B) {
+	for _, n := range []int{16, 32, 64, 128, 256, 1024} {
+		p1 := randomPolynomial(n)
+		p2 := randomPolynomial(n)
+		b.Run("schoolbook/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				mulSchoolbook(p1, p2)
+			}
+		})
+		b.Run("karatsuba/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				mulKaratsuba(p1, p2)
+			}
+		})
+		b.Run("fft/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				mulFFT(p1, p2)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipp
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/transcript"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidSRSSize = errors.New("SRS size must be a power of 2")
+	ErrInvalidSize    = errors.New("invalid vector size (not a power of 2, larger than SRS or different lengths)")
+	ErrInvalidProof   = errors.New("invalid proof size or element not in GT")
+	ErrZeroChallenge  = errors.New("challenge is zero")
+	ErrVerifyTIPP     = errors.New("can't verify TIPP proof")
+	ErrVerifyMIPP     = errors.New("can't verify MIPP proof")
+)
+
+// ProvingKey used to commit to vectors and to prove inner pairing products
+type ProvingKey struct {
+	G1 [2][]bls12381.G1Affine // [aⁱ]G₁ and [bⁱ]G₁, for i < 2n
+	G2 [2][]bls12381.G2Affine // [aⁱ]G₂ and [bⁱ]G₂, for i < n
+}
+
+// VerifyingKey used to verify inner pairing product proofs
+type VerifyingKey struct {
+	G1   [3]bls12381.G1Affine // [G₁, [a]G₁, [b]G₁]
+	G2   [3]bls12381.G2Affine // [G₂, [a]G₂, [b]G₂]
+	Size uint64               // n, the maximal size of committed vectors
+}
+
+// SRS must be computed through MPC and comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// Commitment to a pair of vectors A ∈ G₁ᵐ, B ∈ G₂ᵐ, or to a single vector C ∈ G₁ᵐ
+type Commitment struct {
+	T, U bls12381.GT
+}
+
+// NewSRS returns a new SRS for vectors of size at most size, using a and b as randomness source
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRS(size uint64, bA, bB *big.Int) (*SRS, error) {
+	if size == 0 || size&(size-1) != 0 {
+		return nil, ErrInvalidSRSSize
+	}
+
+	var srs SRS
+	_, _, gen1Aff, gen2Aff := bls12381.Generators()
+	srs.Vk.G1[0] = gen1Aff
+	srs.Vk.G2[0] = gen2Aff
+	srs.Vk.Size = size
+
+	for k, s := range []*big.Int{bA, bB} {
+		var secret fr.Element
+		secret.SetBigInt(s)
+		powers := make([]fr.Element, 2*size)
+		powers[0].SetOne()
+		for i := 1; i < len(powers); i++ {
+			powers[i].Mul(&powers[i-1], &secret)
+		}
+		srs.Pk.G1[k] = bls12381.BatchScalarMultiplicationG1(&gen1Aff, powers)
+		srs.Pk.G2[k] = bls12381.BatchScalarMultiplicationG2(&gen2Aff, powers[:size])
+		srs.Vk.G1[k+1] = srs.Pk.G1[k][1]
+		srs.Vk.G2[k+1].ScalarMultiplication(&gen2Aff, s)
+	}
+
+	return &srs, nil
+}
+
+// CommitPair commits to A ∈ G₁ᵐ and B ∈ G₂ᵐ, for m ≤ n a power of 2:
+//
+//	T = ∏ e(Aᵢ, [aⁱ]G₂)·e([aⁿ⁺ⁱ]G₁, Bᵢ)
+//	U = ∏ e(Aᵢ, [bⁱ]G₂)·e([bⁿ⁺ⁱ]G₁, Bᵢ)
+func CommitPair(A []bls12381.G1Affine, B []bls12381.G2Affine, pk *ProvingKey) (Commitment, error) {
+	n, m := len(pk.G2[0]), len(A)
+	if !validSize(m, len(B), n) {
+		return Commitment{}, ErrInvalidSize
+	}
+	res, err := pairAll(
+		[][]bls12381.G1Affine{concatG1(A, pk.G1[0][n:n+m]), concatG1(A, pk.G1[1][n:n+m])},
+		[][]bls12381.G2Affine{concatG2(pk.G2[0][:m], B), concatG2(pk.G2[1][:m], B)},
+	)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment{T: res[0], U: res[1]}, nil
+}
+
+// CommitG1 commits to C ∈ G₁ᵐ, for m ≤ n a power of 2:
+//
+//	T = ∏ e(Cᵢ, [aⁱ]G₂)
+//	U = ∏ e(Cᵢ, [bⁱ]G₂)
+func CommitG1(C []bls12381.G1Affine, pk *ProvingKey) (Commitment, error) {
+	n, m := len(pk.G2[0]), len(C)
+	if !validSize(m, m, n) {
+		return Commitment{}, ErrInvalidSize
+	}
+	res, err := pairAll(
+		[][]bls12381.G1Affine{C, C},
+		[][]bls12381.G2Affine{pk.G2[0][:m], pk.G2[1][:m]},
+	)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment{T: res[0], U: res[1]}, nil
+}
+
+// validSize returns true if m == m2 and m ≤ n is a power of 2
+func validSize(m, m2, n int) bool {
+	return m == m2 && m > 0 && m <= n && m&(m-1) == 0
+}
+
+// validRounds returns true if a proof with k rounds is for vectors of size 2ᵏ ≤ n
+func validRounds(k int, n uint64) bool {
+	return k < 64 && uint64(1)<<k <= n
+}
+
+// verifyKeys checks the KZG openings at z of the folded keys V = ([f_v(a)]G₂, [f_v(b)]G₂)
+// and, if W is not nil, W = ([f_w(a)]G₁, [f_w(b)]G₁), where yV = f_v(z) and yW = f_w(z).
+//
+// The four equations e([s]G₁ - [z]G₁, πᵥ) = e(G₁, V - [yV]G₂) and
+// e(π_w, [s]G₂ - [z]G₂) = e(W - [yW]G₁, G₂) are combined with random coefficients
+// into a single pairing check.
+func (vk *VerifyingKey) verifyKeys(z, yV fr.Element, V, openingV *[2]bls12381.G2Affine, yW *fr.Element, W, openingW *[2]bls12381.G1Affine) (bool, error) {
+	var zNeg, rho fr.Element
+	var bz, bRho, by big.Int
+	zNeg.Neg(&z)
+	zNeg.BigInt(&bz)
+
+	var zG1Neg bls12381.G1Affine
+	var zG2Neg bls12381.G2Affine
+	zG1Neg.ScalarMultiplication(&vk.G1[0], &bz)
+	zG2Neg.ScalarMultiplication(&vk.G2[0], &bz)
+
+	P := make([]bls12381.G1Affine, 0, 6)
+	Q := make([]bls12381.G2Affine, 0, 6)
+
+	// ∑ ρₖ(Vₖ - [yV]G₂)
+	var yVG2, sumV, t2 bls12381.G2Affine
+	yV.BigInt(&by)
+	yVG2.ScalarMultiplication(&vk.G2[0], &by)
+	for k := 0; k < 2; k++ {
+		rho.SetRandom()
+		rho.BigInt(&bRho)
+		var p bls12381.G1Affine
+		p.Add(&vk.G1[k+1], &zG1Neg).ScalarMultiplication(&p, &bRho)
+		P = append(P, p)
+		Q = append(Q, openingV[k])
+		t2.Sub(&V[k], &yVG2).ScalarMultiplication(&t2, &bRho)
+		sumV.Add(&sumV, &t2)
+	}
+	var g1Neg bls12381.G1Affine
+	g1Neg.Neg(&vk.G1[0])
+	P = append(P, g1Neg)
+	Q = append(Q, sumV)
+
+	if W != nil {
+		// ∑ ρₖ(Wₖ - [yW]G₁)
+		var yWG1, sumW, t1 bls12381.G1Affine
+		yW.BigInt(&by)
+		yWG1.ScalarMultiplication(&vk.G1[0], &by)
+		for k := 0; k < 2; k++ {
+			rho.SetRandom()
+			rho.BigInt(&bRho)
+			var p bls12381.G1Affine
+			p.ScalarMultiplication(&openingW[k], &bRho)
+			P = append(P, p)
+			var q bls12381.G2Affine
+			q.Add(&vk.G2[k+1], &zG2Neg)
+			Q = append(Q, q)
+			t1.Sub(&W[k], &yWG1).ScalarMultiplication(&t1, &bRho)
+			sumW.Add(&sumW, &t1)
+		}
+		sumW.Neg(&sumW)
+		P = append(P, sumW)
+		Q = append(Q, vk.G2[0])
+	}
+
+	return bls12381.PairingCheck(P, Q)
+}
+
+// foldedKey returns the coefficients of f(X) = ∏ⱼ (1 + cⱼ·X^{m/2ʲ⁺¹}), m = 2^len(c).
+// A key (Kᵢ = [sⁱ]G)ᵢ folded in round j as K' = K_L + [cⱼ]K_R ends as [f(s)]G.
+func foldedKey(c []fr.Element) []fr.Element {
+	res := make([]fr.Element, 1, 1<<len(c))
+	res[0].SetOne()
+	for j := len(c) - 1; j >= 0; j-- {
+		l := len(res)
+		for i := 0; i < l; i++ {
+			var t fr.Element
+			t.Mul(&res[i], &c[j])
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// evalFoldedKey returns f(z) = ∏ⱼ (1 + cⱼ·z^{m/2ʲ⁺¹}), see foldedKey
+func evalFoldedKey(c []fr.Element, z fr.Element) fr.Element {
+	var res, t, one fr.Element
+	res.SetOne()
+	one.SetOne()
+	for j := len(c) - 1; j >= 0; j-- {
+		t.Mul(&c[j], &z).Add(&t, &one)
+		res.Mul(&res, &t)
+		z.Square(&z)
+	}
+	return res
+}
+
+// wCoefficients returns the folding coefficients xⱼ·r^{-m/2ʲ⁺¹} of the key w, rescaled by
+// the powers of r⁻¹ and folded with the challenges x
+func wCoefficients(x []fr.Element, rInv fr.Element) []fr.Element {
+	res := make([]fr.Element, len(x))
+	for j := len(x) - 1; j >= 0; j-- {
+		res[j].Mul(&x[j], &rInv)
+		rInv.Square(&rInv)
+	}
+	return res
+}
+
+// quotient returns the coefficients of (f - f(z))/(X - z)
+func quotient(f []fr.Element, z fr.Element) []fr.Element {
+	q := make([]fr.Element, len(f)-1)
+	var carry fr.Element
+	for i := len(f) - 1; i >= 1; i-- {
+		carry.Mul(&carry, &z).Add(&carry, &f[i])
+		q[i-1] = carry
+	}
+	return q
+}
+
+// openG1 returns the KZG opening [(f(s) - f(z))/(s - z)]G₁, where key = ([sⁱ]G₁)ᵢ
+func openG1(f []fr.Element, z fr.Element, key []bls12381.G1Affine) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	q := quotient(f, z)
+	if len(q) == 0 {
+		return res, nil
+	}
+	_, err := res.MultiExp(key[:len(q)], q, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// openG2 returns the KZG opening [(f(s) - f(z))/(s - z)]G₂, where key = ([sⁱ]G₂)ᵢ
+func openG2(f []fr.Element, z fr.Element, key []bls12381.G2Affine) (bls12381.G2Affine, error) {
+	var res bls12381.G2Affine
+	q := quotient(f, z)
+	if len(q) == 0 {
+		return res, nil
+	}
+	_, err := res.MultiExp(key[:len(q)], q, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// pairAll returns the multi-pairings e(P[i], Q[i]), computed concurrently
+func pairAll(P [][]bls12381.G1Affine, Q [][]bls12381.G2Affine) ([]bls12381.GT, error) {
+	res := make([]bls12381.GT, len(P))
+	errs := make([]error, len(P))
+	parallel.Execute(len(P), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i], errs[i] = bls12381.Pair(P[i], Q[i])
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// foldGT sets z to left^x · z · right^{x⁻¹}
+func foldGT(z, left, right *bls12381.GT, x, xInv fr.Element) error {
+	var t bls12381.GT
+	if _, err := t.MultiExp([]bls12381.GT{*left, *right}, []fr.Element{x, xInv}); err != nil {
+		return err
+	}
+	z.Mul(z, &t)
+	return nil
+}
+
+// inGT returns true if all the commitments are in GT
+func inGT(c []Commitment) bool {
+	for i := range c {
+		if !c[i].T.IsInSubGroup() || !c[i].U.IsInSubGroup() {
+			return false
+		}
+	}
+	return true
+}
+
+// foldG1 returns L + [x]R
+func foldG1(L, R []bls12381.G1Affine, x fr.Element) []bls12381.G1Affine {
+	res := make([]bls12381.G1Affine, len(L))
+	var bx big.Int
+	x.BigInt(&bx)
+	parallel.Execute(len(L), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].ScalarMultiplication(&R[i], &bx).Add(&res[i], &L[i])
+		}
+	})
+	return res
+}
+
+// foldG2 returns L + [x]R
+func foldG2(L, R []bls12381.G2Affine, x fr.Element) []bls12381.G2Affine {
+	res := make([]bls12381.G2Affine, len(L))
+	var bx big.Int
+	x.BigInt(&bx)
+	parallel.Execute(len(L), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].ScalarMultiplication(&R[i], &bx).Add(&res[i], &L[i])
+		}
+	})
+	return res
+}
+
+// scaleG1 returns ([sᵢ]Pᵢ)ᵢ
+func scaleG1(P []bls12381.G1Affine, s []fr.Element) []bls12381.G1Affine {
+	res := make([]bls12381.G1Affine, len(P))
+	parallel.Execute(len(P), func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			s[i].BigInt(&b)
+			res[i].ScalarMultiplication(&P[i], &b)
+		}
+	})
+	return res
+}
+
+// scaleG2 returns ([sᵢ]Qᵢ)ᵢ
+func scaleG2(Q []bls12381.G2Affine, s []fr.Element) []bls12381.G2Affine {
+	res := make([]bls12381.G2Affine, len(Q))
+	parallel.Execute(len(Q), func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			s[i].BigInt(&b)
+			res[i].ScalarMultiplication(&Q[i], &b)
+		}
+	})
+	return res
+}
+
+// powers returns (1, x, x², ..., xᵐ⁻¹)
+func powers(x fr.Element, m int) []fr.Element {
+	res := make([]fr.Element, m)
+	res[0].SetOne()
+	for i := 1; i < m; i++ {
+		res[i].Mul(&res[i-1], &x)
+	}
+	return res
+}
+
+func concatG1(a, b []bls12381.G1Affine) []bls12381.G1Affine {
+	return append(append(make([]bls12381.G1Affine, 0, len(a)+len(b)), a...), b...)
+}
+
+func concatG2(a, b []bls12381.G2Affine) []bls12381.G2Affine {
+	return append(append(make([]bls12381.G2Affine, 0, len(a)+len(b)), a...), b...)
+}
+
+// appendGT appends GT elements to the transcript, under a single label
+func appendGT(tr *transcript.Transcript, label string, elements ...bls12381.GT) error {
+	buf := make([]byte, 0, len(elements)*bls12381.SizeOfGT)
+	for i := range elements {
+		b := elements[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+	return tr.AppendBytes(label, buf)
+}
+
+// challenge returns a non-zero round challenge x and its inverse
+func challenge(tr *transcript.Transcript) (x, xInv fr.Element, err error) {
+	if x, err = tr.ChallengeScalar("x"); err != nil {
+		return
+	}
+	if x.IsZero() {
+		err = ErrZeroChallenge
+		return
+	}
+	xInv.Inverse(&x)
+	return
+}
@@ -0,0 +1,318 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/transcript"
+)
+
+// Test SRS re-used across tests of the inner pairing product arguments
+var testSrs *SRS
+
+func init() {
+	var err error
+	testSrs, err = NewSRS(8, big.NewInt(42), big.NewInt(43))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomG1(m int) []bls12381.G1Affine {
+	s := make([]fr.Element, m)
+	for i := range s {
+		s[i].SetRandom()
+	}
+	_, _, g1, _ := bls12381.Generators()
+	return bls12381.BatchScalarMultiplicationG1(&g1, s)
+}
+
+func randomG2(m int) []bls12381.G2Affine {
+	s := make([]fr.Element, m)
+	for i := range s {
+		s[i].SetRandom()
+	}
+	_, _, _, g2 := bls12381.Generators()
+	return bls12381.BatchScalarMultiplicationG2(&g2, s)
+}
+
+// challengeR binds the commitments to a new transcript and derives r from it
+func challengeR(t testing.TB, com ...Commitment) (*transcript.Transcript, fr.Element) {
+	tr := transcript.New("ipp test")
+	for i := range com {
+		require.NoError(t, appendGT(tr, "commitment", com[i].T, com[i].U))
+	}
+	r, err := tr.ChallengeScalar("r")
+	require.NoError(t, err)
+	return tr, r
+}
+
+func TestNewSRS(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := NewSRS(6, big.NewInt(42), big.NewInt(43))
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+
+	assert.Len(testSrs.Pk.G1[0], 16)
+	assert.Len(testSrs.Pk.G2[1], 8)
+	var a, b bls12381.G2Affine
+	a.ScalarMultiplication(&testSrs.Vk.G2[0], big.NewInt(42*42*42))
+	b.ScalarMultiplication(&testSrs.Vk.G2[0], big.NewInt(43*43*43))
+	assert.True(a.Equal(&testSrs.Pk.G2[0][3]))
+	assert.True(b.Equal(&testSrs.Pk.G2[1][3]))
+	assert.True(testSrs.Vk.G1[2].Equal(&testSrs.Pk.G1[1][1]))
+}
+
+func TestCommit(t *testing.T) {
+	assert := require.New(t)
+
+	A, B := randomG1(4), randomG2(4)
+	com, err := CommitPair(A, B, &testSrs.Pk)
+	assert.NoError(err)
+
+	// the commitment of vectors padded with zeros is the same
+	padded, err := CommitPair(append(A, make([]bls12381.G1Affine, 4)...), append(B, make([]bls12381.G2Affine, 4)...), &testSrs.Pk)
+	assert.NoError(err)
+	assert.True(com.T.Equal(&padded.T) && com.U.Equal(&padded.U))
+
+	// but changes with the vectors
+	B[3] = B[2]
+	other, err := CommitPair(A, B, &testSrs.Pk)
+	assert.NoError(err)
+	assert.False(com.T.Equal(&other.T))
+
+	cA, err := CommitG1(A, &testSrs.Pk)
+	assert.NoError(err)
+	expected, err := bls12381.Pair(A, testSrs.Pk.G2[0][:4])
+	assert.NoError(err)
+	assert.True(expected.Equal(&cA.T))
+
+	_, err = CommitPair(A, B[:2], &testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidSize)
+	_, err = CommitG1(randomG1(3), &testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidSize)
+	_, err = CommitG1(randomG1(16), &testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidSize)
+}
+
+func TestTIPP(t *testing.T) {
+	assert := require.New(t)
+
+	for _, m := range []int{1, 2, 8} {
+		A, B := randomG1(m), randomG2(m)
+		com, err := CommitPair(A, B, &testSrs.Pk)
+		assert.NoError(err)
+
+		tr, r := challengeR(t, com)
+		proof, err := ProveTIPP(A, B, &com, r, &testSrs.Pk, tr)
+		assert.NoError(err)
+		assert.Len(proof.ZL, len(proof.CR))
+
+		// Z = ∏ e(Aᵢ, Bᵢ)^{rⁱ}
+		var expected, tmp bls12381.GT
+		var e big.Int
+		expected.SetOne()
+		for i, ri := range powers(r, m) {
+			p, err := bls12381.Pair(A[i:i+1], B[i:i+1])
+			assert.NoError(err)
+			tmp.Exp(p, ri.BigInt(&e))
+			expected.Mul(&expected, &tmp)
+		}
+		assert.True(expected.Equal(&proof.Z))
+
+		tr, r = challengeR(t, com)
+		assert.NoError(VerifyTIPP(&com, r, &proof, &testSrs.Vk, tr))
+
+		// wrong inner product
+		wrong := proof
+		wrong.Z.Square(&proof.Z)
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyTIPP(&com, r, &wrong, &testSrs.Vk, tr), ErrVerifyTIPP)
+
+		// wrong commitment
+		other, err := CommitPair(randomG1(m), B, &testSrs.Pk)
+		assert.NoError(err)
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyTIPP(&other, r, &proof, &testSrs.Vk, tr), ErrVerifyTIPP)
+
+		// wrong folded key
+		wrong = proof
+		wrong.W[1] = proof.W[0]
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyTIPP(&com, r, &wrong, &testSrs.Vk, tr), ErrVerifyTIPP)
+
+		// transcript in a different state
+		tr, r = challengeR(t, com, com)
+		assert.Error(VerifyTIPP(&com, r, &proof, &testSrs.Vk, tr))
+	}
+
+	// truncated proof
+	A, B := randomG1(4), randomG2(4)
+	com, err := CommitPair(A, B, &testSrs.Pk)
+	assert.NoError(err)
+	tr, r := challengeR(t, com)
+	proof, err := ProveTIPP(A, B, &com, r, &testSrs.Pk, tr)
+	assert.NoError(err)
+	proof.CR = proof.CR[:1]
+	tr, r = challengeR(t, com)
+	assert.ErrorIs(VerifyTIPP(&com, r, &proof, &testSrs.Vk, tr), ErrInvalidProof)
+}
+
+func TestMIPP(t *testing.T) {
+	assert := require.New(t)
+
+	for _, m := range []int{1, 2, 8} {
+		C := randomG1(m)
+		com, err := CommitG1(C, &testSrs.Pk)
+		assert.NoError(err)
+
+		tr, r := challengeR(t, com)
+		proof, err := ProveMIPP(C, &com, r, &testSrs.Pk, tr)
+		assert.NoError(err)
+
+		// Z = ∑ [rⁱ]Cᵢ
+		var expected, tmp bls12381.G1Jac
+		var e big.Int
+		for i, ri := range powers(r, m) {
+			expected.AddAssign(tmp.ScalarMultiplicationAffine(&C[i], ri.BigInt(&e)))
+		}
+		var z bls12381.G1Affine
+		z.FromJacobian(&expected)
+		assert.True(z.Equal(&proof.Z))
+
+		tr, r = challengeR(t, com)
+		assert.NoError(VerifyMIPP(&com, r, &proof, &testSrs.Vk, tr))
+
+		// wrong inner product
+		wrong := proof
+		wrong.Z.Double(&proof.Z)
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyMIPP(&com, r, &wrong, &testSrs.Vk, tr), ErrVerifyMIPP)
+
+		// wrong commitment
+		other, err := CommitG1(randomG1(m), &testSrs.Pk)
+		assert.NoError(err)
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyMIPP(&other, r, &proof, &testSrs.Vk, tr), ErrVerifyMIPP)
+
+		// wrong folded key
+		wrong = proof
+		wrong.V[0] = testSrs.Vk.G2[1]
+		tr, r = challengeR(t, com)
+		assert.ErrorIs(VerifyMIPP(&com, r, &wrong, &testSrs.Vk, tr), ErrVerifyMIPP)
+	}
+}
+
+// TestAggregation aggregates pairing equations e(Aᵢ, Bᵢ) = e(Cᵢ, D) with the same
+// challenge r in TIPP and MIPP, as SnarkPack does for Groth16 proofs.
+func TestAggregation(t *testing.T) {
+	assert := require.New(t)
+
+	const m = 8
+
+	// Cᵢ such that e(Cᵢ, D) = e(Aᵢ, Bᵢ): Aᵢ = [αᵢ]G₁, Bᵢ = [βᵢ]G₂, D = [δ]G₂, Cᵢ = [αᵢβᵢ/δ]G₁
+	s := make([]fr.Element, 2*m+1)
+	for i := range s {
+		s[i].SetRandom()
+	}
+	_, _, g1, g2 := bls12381.Generators()
+	var b big.Int
+	var delta fr.Element
+	var D bls12381.G2Affine
+	D.ScalarMultiplication(&g2, s[2*m].BigInt(&b))
+	delta.Inverse(&s[2*m])
+	c := make([]fr.Element, m)
+	for i := 0; i < m; i++ {
+		c[i].Mul(&s[i], &s[m+i]).Mul(&c[i], &delta)
+	}
+	A := bls12381.BatchScalarMultiplicationG1(&g1, s[:m])
+	B := bls12381.BatchScalarMultiplicationG2(&g2, s[m:2*m])
+	C := bls12381.BatchScalarMultiplicationG1(&g1, c)
+
+	comAB, err := CommitPair(A, B, &testSrs.Pk)
+	assert.NoError(err)
+	comC, err := CommitG1(C, &testSrs.Pk)
+	assert.NoError(err)
+
+	tr, r := challengeR(t, comAB, comC)
+	tipp, err := ProveTIPP(A, B, &comAB, r, &testSrs.Pk, tr)
+	assert.NoError(err)
+	mipp, err := ProveMIPP(C, &comC, r, &testSrs.Pk, tr)
+	assert.NoError(err)
+
+	// the verifier checks both proofs, and e(Z_C, D) = Z_AB
+	tr, r = challengeR(t, comAB, comC)
+	assert.NoError(VerifyTIPP(&comAB, r, &tipp, &testSrs.Vk, tr))
+	assert.NoError(VerifyMIPP(&comC, r, &mipp, &testSrs.Vk, tr))
+	zC, err := bls12381.Pair([]bls12381.G1Affine{mipp.Z}, []bls12381.G2Affine{D})
+	assert.NoError(err)
+	assert.True(zC.Equal(&tipp.Z))
+}
+
+func BenchmarkTIPP(b *testing.B) {
+	const m = 8
+	A, B := randomG1(m), randomG2(m)
+	com, err := CommitPair(A, B, &testSrs.Pk)
+	require.NoError(b, err)
+
+	b.Run("prove", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr, r := challengeR(b, com)
+			_, _ = ProveTIPP(A, B, &com, r, &testSrs.Pk, tr)
+		}
+	})
+
+	tr, r := challengeR(b, com)
+	proof, err := ProveTIPP(A, B, &com, r, &testSrs.Pk, tr)
+	require.NoError(b, err)
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr, r := challengeR(b, com)
+			_ = VerifyTIPP(&com, r, &proof, &testSrs.Vk, tr)
+		}
+	})
+}
+
+func BenchmarkMIPP(b *testing.B) {
+	const m = 8
+	C := randomG1(m)
+	com, err := CommitG1(C, &testSrs.Pk)
+	require.NoError(b, err)
+
+	b.Run("prove", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr, r := challengeR(b, com)
+			_, _ = ProveMIPP(C, &com, r, &testSrs.Pk, tr)
+		}
+	})
+
+	tr, r := challengeR(b, com)
+	proof, err := ProveMIPP(C, &com, r, &testSrs.Pk, tr)
+	require.NoError(b, err)
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr, r := challengeR(b, com)
+			_ = VerifyMIPP(&com, r, &proof, &testSrs.Vk, tr)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package vector
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+const testSize = 16
+
+var testPk ProvingKey
+var testVk VerifyingKey
+var tau fr.Element
+
+func init() {
+	const bTau = 42
+	tau.SetUint64(bTau)
+	srs, err := kzg.NewSRS(testSize, big.NewInt(bTau))
+	if err != nil {
+		panic(err)
+	}
+	testPk, testVk, err = Setup(srs, testSize)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomVector() []fr.Element {
+	values := make([]fr.Element, testSize)
+	for i := range values {
+		values[i].SetRandom()
+	}
+	return values
+}
+
+func TestSetup(t *testing.T) {
+	assert := require.New(t)
+
+	// [(Lᵢ(τ)-1)/(τ-ωⁱ)]G₁, with Lᵢ(τ) = ωⁱ/n·(τⁿ-1)/(τ-ωⁱ)
+	_, _, g1, _ := bls12381.Generators()
+	var n, one, tauN, omegaI, l, d fr.Element
+	n.SetUint64(testSize)
+	one.SetOne()
+	tauN.Exp(tau, big.NewInt(testSize)).Sub(&tauN, &one)
+	omegaI.SetOne()
+	for i := 0; i < testSize; i++ {
+		d.Sub(&tau, &omegaI).Inverse(&d)
+		l.Mul(&omegaI, &tauN).Div(&l, &n).Mul(&l, &d)
+
+		var expected bls12381.G1Affine
+		expected.ScalarMultiplication(&g1, l.BigInt(new(big.Int)))
+		assert.True(expected.Equal(&testPk.Lagrange[i]), "wrong Lagrange basis")
+
+		l.Sub(&l, &one).Mul(&l, &d)
+		expected.ScalarMultiplication(&g1, l.BigInt(new(big.Int)))
+		assert.True(expected.Equal(&testPk.Updates[i]), "wrong update key")
+
+		omegaI.Mul(&omegaI, &testPk.Generator)
+	}
+
+	srs, err := kzg.NewSRS(testSize, big.NewInt(42))
+	assert.NoError(err)
+	_, _, err = Setup(srs, 3)
+	assert.ErrorIs(err, ErrInvalidSize)
+	_, _, err = Setup(srs, 2*testSize)
+	assert.ErrorIs(err, ErrInvalidSize)
+}
+
+func TestOpen(t *testing.T) {
+	assert := require.New(t)
+
+	values := randomVector()
+	commitment, err := Commit(values, testPk)
+	assert.NoError(err)
+
+	for i := uint64(0); i < testSize; i++ {
+		proof, err := Open(values, i, testPk)
+		assert.NoError(err)
+		assert.True(proof.ClaimedValue.Equal(&values[i]))
+		assert.NoError(Verify(&commitment, i, &proof, testVk))
+		assert.Error(Verify(&commitment, (i+1)%testSize, &proof, testVk))
+	}
+
+	_, err = Open(values, testSize, testPk)
+	assert.ErrorIs(err, ErrInvalidIndex)
+	_, err = Commit(values[1:], testPk)
+	assert.ErrorIs(err, ErrInvalidSize)
+}
+
+func TestUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	values := randomVector()
+	v, err := NewVector(values, testPk)
+	assert.NoError(err)
+	for _, i := range []uint64{0, 3, 7} {
+		_, err = v.Open(i)
+		assert.NoError(err)
+	}
+
+	for _, index := range []uint64{3, 5, 0, testSize - 1} {
+		var value fr.Element
+		value.SetRandom()
+		assert.NoError(v.Set(index, value))
+		values[index] = value
+
+		expected, err := Commit(values, testPk)
+		assert.NoError(err)
+		commitment := v.Commitment()
+		assert.True(expected.Equal(&commitment), "wrong commitment update")
+
+		for _, i := range []uint64{0, 3, 7} {
+			proof, err := v.Open(i)
+			assert.NoError(err)
+			fresh, err := Open(values, i, testPk)
+			assert.NoError(err)
+			assert.True(fresh.H.Equal(&proof.H), "wrong proof update")
+			assert.True(fresh.ClaimedValue.Equal(&proof.ClaimedValue), "wrong claimed value")
+			assert.NoError(Verify(&commitment, i, &proof, testVk))
+		}
+	}
+
+	assert.ErrorIs(v.Set(testSize, fr.Element{}), ErrInvalidIndex)
+}
+
+func BenchmarkUpdateProof(b *testing.B) {
+	values := randomVector()
+	proof, _ := Open(values, 1, testPk)
+	var delta fr.Element
+	delta.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = UpdateProof(&proof, 1, 2, delta, testPk)
+	}
+}
@@ -18,10 +18,13 @@ package bls12381
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"io"
 	"math/big"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/leanovate/gopter"
@@ -162,6 +165,275 @@ func TestEncoder(t *testing.T) {
 
 }
 
+func TestGTSerialization(t *testing.T) {
+	t.Parallel()
+
+	// in[0] --> identity
+	in := make([]GT, 3)
+	in[0].SetOne()
+	var err error
+	in[1], err = Pair([]G1Affine{g1GenAff}, []G2Affine{g2GenAff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in[2].Exp(in[1], new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var enc *Encoder
+		size := SizeOfGTCompressed
+		if raw {
+			enc = NewEncoder(&buf, RawEncoding())
+			size = SizeOfGT
+		} else {
+			enc = NewEncoder(&buf)
+		}
+		for i := range in {
+			if err := enc.Encode(&in[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Encode(in); err != nil {
+			t.Fatal(err)
+		}
+		if enc.BytesWritten() != int64(2*len(in)*size+4) {
+			t.Fatal("unexpected size of encoded GT elements")
+		}
+
+		dec := NewDecoder(&buf)
+		for i := range in {
+			var out GT
+			if err := dec.Decode(&out); err != nil {
+				t.Fatal(err)
+			}
+			if !out.Equal(&in[i]) {
+				t.Fatal("decode(encode(GT)) failed")
+			}
+		}
+		var out []GT
+		if err := dec.Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Fatal("decode(encode(slice(GT))) failed")
+		}
+		if dec.BytesRead() != enc.BytesWritten() {
+			t.Fatal("bytes read don't match bytes written")
+		}
+	}
+
+	// an element of the torus, outside of GT
+	var a, b GT
+	a.SetRandom()
+	b.Inverse(&a)
+	a.Conjugate(&a).Mul(&a, &b)
+	if a.IsInSubGroup() {
+		t.Skip("random element unexpectedly in GT")
+	}
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var enc *Encoder
+		if raw {
+			enc = NewEncoder(&buf, RawEncoding())
+		} else {
+			enc = NewEncoder(&buf)
+		}
+		if err := enc.Encode(&a); err != nil {
+			t.Fatal(err)
+		}
+		encoded := buf.Bytes()
+
+		var out GT
+		if err := NewDecoder(bytes.NewReader(encoded)).Decode(&out); err == nil {
+			t.Fatal("decoding an element outside of GT should fail")
+		}
+		if err := NewDecoder(bytes.NewReader(encoded), NoSubgroupChecks()).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !out.Equal(&a) {
+			t.Fatal("decode(encode(GT)) failed without subgroup checks")
+		}
+	}
+}
+
+func TestLineTableSerialization(t *testing.T) {
+	t.Parallel()
+
+	var q G2Affine
+	q.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	lines := PrecomputeLines(q)
+
+	var buf bytes.Buffer
+	written, err := lines.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(SizeOfLineTable) || buf.Len() != SizeOfLineTable {
+		t.Fatal("unexpected size of the encoded lines")
+	}
+
+	var decoded LineTable
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || decoded != lines {
+		t.Fatal("ReadFrom(WriteTo(lines)) failed")
+	}
+
+	// the decoded table computes the same pairing
+	var p G1Affine
+	p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	e1, _ := Pair([]G1Affine{p}, []G2Affine{q})
+	e2, _ := PairFixedQ([]G1Affine{p}, [][2][len(decoded[0])]LineEvaluationAff{decoded})
+	if !e1.Equal(&e2) {
+		t.Fatal("pairing with the decoded lines failed")
+	}
+
+	// truncated and non canonical inputs
+	b, _ := lines.MarshalBinary()
+	if _, err := decoded.ReadFrom(bytes.NewReader(b[:SizeOfLineTable-1])); err == nil {
+		t.Fatal("decoding truncated lines should fail")
+	}
+	for i := SizeOfLineTable - fp.Bytes; i < SizeOfLineTable; i++ {
+		b[i] = 0xff
+	}
+	if err := decoded.UnmarshalBinary(b); err == nil {
+		t.Fatal("decoding non canonical lines should fail")
+	}
+	if decoded != lines {
+		t.Fatal("a failed decoding must leave the lines unchanged")
+	}
+}
+
+func TestJSONAndText(t *testing.T) {
+	t.Parallel()
+
+	type S struct {
+		A G1Affine
+		B G2Affine
+		C []G1Affine
+	}
+	var in S
+	in.A.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	in.B.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	in.C = make([]G1Affine, 2)                                                  // in.C[0] --> infinity
+	in.C[1].Neg(&in.A)
+
+	encoded, err := json.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out S
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatal("json.Unmarshal(json.Marshal(points)) failed")
+	}
+
+	// schema
+	x, _ := in.A.X.MarshalText()
+	y, _ := in.A.Y.MarshalText()
+	a, _ := json.Marshal(&in.A)
+	if string(a) != `{"x":"`+string(x)+`","y":"`+string(y)+`"}` {
+		t.Fatal("unexpected JSON encoding of G1Affine")
+	}
+	if len(x) != 2+2*fp.Bytes || !strings.HasPrefix(string(x), "0x") {
+		t.Fatal("unexpected hex encoding of fp.Element")
+	}
+
+	// point not on the curve
+	var p G1Affine
+	notOnCurve := strings.Replace(string(a), string(y), string(x), 1)
+	if err := json.Unmarshal([]byte(notOnCurve), &p); err == nil {
+		t.Fatal("decoding a point not on the curve should fail")
+	}
+
+	// text, from the compressed or uncompressed encodings
+	text, err := in.B.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(text) != 2+2*SizeOfG2AffineCompressed {
+		t.Fatal("MarshalText should return the compressed point")
+	}
+	var q G2Affine
+	if err := q.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !q.Equal(&in.B) {
+		t.Fatal("UnmarshalText(MarshalText(point)) failed")
+	}
+	raw := in.B.RawBytes()
+	if err := q.UnmarshalText([]byte(hex.EncodeToString(raw[:]))); err != nil {
+		t.Fatal(err)
+	}
+	if !q.Equal(&in.B) {
+		t.Fatal("UnmarshalText(RawBytes) failed")
+	}
+	if err := q.UnmarshalText(text[:len(text)-2]); err == nil {
+		t.Fatal("decoding a truncated point should fail")
+	}
+}
+
+func TestEIP2537(t *testing.T) {
+	t.Parallel()
+
+	b := g1GenAff.EIP2537Bytes()
+	x := g1GenAff.X.Bytes()
+	if !isZeroed(0, b[:64-fp.Bytes]) || !bytes.Equal(b[64-fp.Bytes:64], x[:]) {
+		t.Fatal("unexpected encoding of the G1 generator")
+	}
+	bQ := g2GenAff.EIP2537Bytes()
+	xA1 := g2GenAff.X.A1.Bytes()
+	if !isZeroed(0, bQ[64:128-fp.Bytes]) || !bytes.Equal(bQ[128-fp.Bytes:128], xA1[:]) {
+		t.Fatal("unexpected encoding of the G2 generator")
+	}
+
+	var p, p2 G1Affine
+	var q, q2 G2Affine
+	p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	q.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64())) //#nosec G404 weak rng is fine here
+	b = p.EIP2537Bytes()
+	if _, err := p2.SetEIP2537Bytes(b[:]); err != nil || !p2.Equal(&p) {
+		t.Fatal("SetEIP2537Bytes(EIP2537Bytes) failed")
+	}
+	bQ = q.EIP2537Bytes()
+	if _, err := q2.SetEIP2537Bytes(bQ[:]); err != nil || !q2.Equal(&q) {
+		t.Fatal("SetEIP2537Bytes(EIP2537Bytes) failed")
+	}
+
+	// infinity
+	var inf G2Affine
+	if _, err := q2.SetEIP2537Bytes(make([]byte, SizeOfG2AffineEIP2537)); err != nil || !q2.Equal(&inf) {
+		t.Fatal("zero bytes should decode to infinity")
+	}
+
+	// non zero padding
+	b[0] = 1
+	if _, err := p2.SetEIP2537Bytes(b[:]); err != ErrInvalidEncoding {
+		t.Fatal("non zero padding should be rejected")
+	}
+
+	scalars := make([]fr.Element, 2)
+	input, err := EIP2537G1MSMInput([]G1Affine{p, p}, scalars)
+	if err != nil || len(input) != 2*(SizeOfG1AffineEIP2537+fr.Bytes) {
+		t.Fatal("unexpected G1 MSM input")
+	}
+	input, err = EIP2537G2MSMInput([]G2Affine{q}, scalars[:1])
+	if err != nil || len(input) != SizeOfG2AffineEIP2537+fr.Bytes {
+		t.Fatal("unexpected G2 MSM input")
+	}
+	input, err = EIP2537PairingInput([]G1Affine{p}, []G2Affine{q})
+	if err != nil || len(input) != SizeOfG1AffineEIP2537+SizeOfG2AffineEIP2537 {
+		t.Fatal("unexpected pairing input")
+	}
+	if _, err = EIP2537PairingInput([]G1Affine{p}, nil); err == nil {
+		t.Fatal("should fail on mismatching lengths")
+	}
+}
+
 func TestIsCompressed(t *testing.T) {
 	t.Parallel()
 	var g1Inf, g1 G1Affine
@@ -0,0 +1,347 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// DefaultMultiExpChunkSize is the default number of points and scalars processed at once by the out-of-core MultiExp
+const DefaultMultiExpChunkSize = 1 << 20
+
+// MultiExpReaderAt computes the multi-exponentiation ∑ᵢ scalars[i]·points[i] without holding
+// the inputs in memory.
+//
+// points must be a []G1Affine encoded with an Encoder using RawEncoding() (uncompressed points, no subgroup checks
+// are performed while reading), and scalars a fr.Vector encoded with fr.Vector.WriteTo (the format read by
+// fr.Vector.AsyncReadFrom). Both can be files, or memory-mapped regions wrapped in a bytes.Reader.
+//
+// The inputs are processed in chunks of chunkSize points; the digits of each chunk are accumulated in buckets
+// that persist across chunks, and the buckets are reduced once at the end.
+// The memory used is O(chunkSize + 2ᶜ⁻¹·fr.Bits/c) points.
+func (p *G1Jac) MultiExpReaderAt(points, scalars io.ReaderAt, chunkSize int, config ecc.MultiExpConfig) (*G1Jac, error) {
+	nbPoints, err := readVectorLen(points)
+	if err != nil {
+		return nil, err
+	}
+	nbScalars, err := readVectorLen(scalars)
+	if err != nil {
+		return nil, err
+	}
+	if nbPoints != nbScalars {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultMultiExpChunkSize
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	c := bestCReader(nbPoints)
+	nbChunks := int(computeNbChunks(c))
+
+	// buckets[j] are the buckets of the j-th c-bit window
+	buckets := make([][]g1JacExtended, nbChunks)
+	for j := range buckets {
+		cj := c
+		if j == nbChunks-1 {
+			cj = lastC(c)
+		}
+		buckets[j] = make([]g1JacExtended, 1<<(cj-1))
+		for k := range buckets[j] {
+			buckets[j][k].setInfinity()
+		}
+	}
+
+	if chunkSize > nbPoints {
+		chunkSize = nbPoints
+	}
+	_points := make([]G1Affine, chunkSize)
+	_scalars := make([]fr.Element, chunkSize)
+	buf := make([]byte, chunkSize*SizeOfG1AffineUncompressed)
+
+	for offset := 0; offset < nbPoints; offset += chunkSize {
+		n := chunkSize
+		if offset+n > nbPoints {
+			n = nbPoints - offset
+		}
+
+		if err := fr.Vector(_scalars[:n]).ReadElementsAt(scalars, int64(offset)); err != nil {
+			return nil, err
+		}
+		digits, _ := partitionScalars(_scalars[:n], c, config.NbTasks)
+
+		if err := readG1AffineAt(points, _points[:n], offset, buf); err != nil {
+			return nil, err
+		}
+
+		// one task per window, each window owns its buckets
+		parallel.Execute(nbChunks, func(start, end int) {
+			for j := start; j < end; j++ {
+				b := buckets[j]
+				for i, digit := range digits[j*n : (j+1)*n] {
+					if digit == 0 {
+						continue
+					}
+					if digit&1 == 0 {
+						b[(digit>>1)-1].addMixed(&_points[i])
+					} else {
+						b[(digit >> 1)].subMixed(&_points[i])
+					}
+				}
+			}
+		}, config.NbTasks)
+	}
+
+	// reduce the buckets of each window: total = ∑ₖ (k+1)·bucket[k]
+	totals := make([]g1JacExtended, nbChunks)
+	parallel.Execute(nbChunks, func(start, end int) {
+		for j := start; j < end; j++ {
+			var runningSum g1JacExtended
+			runningSum.setInfinity()
+			totals[j].setInfinity()
+			for k := len(buckets[j]) - 1; k >= 0; k-- {
+				if !buckets[j][k].IsZero() {
+					runningSum.add(&buckets[j][k])
+				}
+				totals[j].add(&runningSum)
+			}
+		}
+	}, config.NbTasks)
+
+	// combine the windows
+	_p := totals[nbChunks-1]
+	for j := nbChunks - 2; j >= 0; j-- {
+		for l := uint64(0); l < c; l++ {
+			_p.double(&_p)
+		}
+		_p.add(&totals[j])
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// readG1AffineAt decodes len(points) uncompressed points of a []G1Affine encoded with RawEncoding(),
+// starting at the offset-th one. buf must be of size at least len(points)*SizeOfG1AffineUncompressed.
+func readG1AffineAt(r io.ReaderAt, points []G1Affine, offset int, buf []byte) error {
+	buf = buf[:len(points)*SizeOfG1AffineUncompressed]
+	if _, err := r.ReadAt(buf, 4+int64(offset)*SizeOfG1AffineUncompressed); err != nil {
+		return err
+	}
+	var cptErrors uint64
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			b := buf[i*SizeOfG1AffineUncompressed : (i+1)*SizeOfG1AffineUncompressed]
+			if isCompressed(b[0]) {
+				// compressed points would not be at a fixed offset
+				atomic.AddUint64(&cptErrors, 1)
+				return
+			}
+			if _, err := points[i].setBytes(b, false); err != nil {
+				atomic.AddUint64(&cptErrors, 1)
+				return
+			}
+		}
+	})
+	if cptErrors > 0 {
+		return fmt.Errorf("read points: %d points failed validation (points must be uncompressed)", cptErrors)
+	}
+	return nil
+}
+
+// MultiExpReaderAt computes the multi-exponentiation ∑ᵢ scalars[i]·points[i] without holding
+// the inputs in memory.
+//
+// points must be a []G2Affine encoded with an Encoder using RawEncoding() (uncompressed points, no subgroup checks
+// are performed while reading), and scalars a fr.Vector encoded with fr.Vector.WriteTo (the format read by
+// fr.Vector.AsyncReadFrom). Both can be files, or memory-mapped regions wrapped in a bytes.Reader.
+//
+// The inputs are processed in chunks of chunkSize points; the digits of each chunk are accumulated in buckets
+// that persist across chunks, and the buckets are reduced once at the end.
+// The memory used is O(chunkSize + 2ᶜ⁻¹·fr.Bits/c) points.
+func (p *G2Jac) MultiExpReaderAt(points, scalars io.ReaderAt, chunkSize int, config ecc.MultiExpConfig) (*G2Jac, error) {
+	nbPoints, err := readVectorLen(points)
+	if err != nil {
+		return nil, err
+	}
+	nbScalars, err := readVectorLen(scalars)
+	if err != nil {
+		return nil, err
+	}
+	if nbPoints != nbScalars {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultMultiExpChunkSize
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	c := bestCReader(nbPoints)
+	nbChunks := int(computeNbChunks(c))
+
+	// buckets[j] are the buckets of the j-th c-bit window
+	buckets := make([][]g2JacExtended, nbChunks)
+	for j := range buckets {
+		cj := c
+		if j == nbChunks-1 {
+			cj = lastC(c)
+		}
+		buckets[j] = make([]g2JacExtended, 1<<(cj-1))
+		for k := range buckets[j] {
+			buckets[j][k].setInfinity()
+		}
+	}
+
+	if chunkSize > nbPoints {
+		chunkSize = nbPoints
+	}
+	_points := make([]G2Affine, chunkSize)
+	_scalars := make([]fr.Element, chunkSize)
+	buf := make([]byte, chunkSize*SizeOfG2AffineUncompressed)
+
+	for offset := 0; offset < nbPoints; offset += chunkSize {
+		n := chunkSize
+		if offset+n > nbPoints {
+			n = nbPoints - offset
+		}
+
+		if err := fr.Vector(_scalars[:n]).ReadElementsAt(scalars, int64(offset)); err != nil {
+			return nil, err
+		}
+		digits, _ := partitionScalars(_scalars[:n], c, config.NbTasks)
+
+		if err := readG2AffineAt(points, _points[:n], offset, buf); err != nil {
+			return nil, err
+		}
+
+		// one task per window, each window owns its buckets
+		parallel.Execute(nbChunks, func(start, end int) {
+			for j := start; j < end; j++ {
+				b := buckets[j]
+				for i, digit := range digits[j*n : (j+1)*n] {
+					if digit == 0 {
+						continue
+					}
+					if digit&1 == 0 {
+						b[(digit>>1)-1].addMixed(&_points[i])
+					} else {
+						b[(digit >> 1)].subMixed(&_points[i])
+					}
+				}
+			}
+		}, config.NbTasks)
+	}
+
+	// reduce the buckets of each window: total = ∑ₖ (k+1)·bucket[k]
+	totals := make([]g2JacExtended, nbChunks)
+	parallel.Execute(nbChunks, func(start, end int) {
+		for j := start; j < end; j++ {
+			var runningSum g2JacExtended
+			runningSum.setInfinity()
+			totals[j].setInfinity()
+			for k := len(buckets[j]) - 1; k >= 0; k-- {
+				if !buckets[j][k].IsZero() {
+					runningSum.add(&buckets[j][k])
+				}
+				totals[j].add(&runningSum)
+			}
+		}
+	}, config.NbTasks)
+
+	// combine the windows
+	_p := totals[nbChunks-1]
+	for j := nbChunks - 2; j >= 0; j-- {
+		for l := uint64(0); l < c; l++ {
+			_p.double(&_p)
+		}
+		_p.add(&totals[j])
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// readG2AffineAt decodes len(points) uncompressed points of a []G2Affine encoded with RawEncoding(),
+// starting at the offset-th one. buf must be of size at least len(points)*SizeOfG2AffineUncompressed.
+func readG2AffineAt(r io.ReaderAt, points []G2Affine, offset int, buf []byte) error {
+	buf = buf[:len(points)*SizeOfG2AffineUncompressed]
+	if _, err := r.ReadAt(buf, 4+int64(offset)*SizeOfG2AffineUncompressed); err != nil {
+		return err
+	}
+	var cptErrors uint64
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			b := buf[i*SizeOfG2AffineUncompressed : (i+1)*SizeOfG2AffineUncompressed]
+			if isCompressed(b[0]) {
+				// compressed points would not be at a fixed offset
+				atomic.AddUint64(&cptErrors, 1)
+				return
+			}
+			if _, err := points[i].setBytes(b, false); err != nil {
+				atomic.AddUint64(&cptErrors, 1)
+				return
+			}
+		}
+	})
+	if cptErrors > 0 {
+		return fmt.Errorf("read points: %d points failed validation (points must be uncompressed)", cptErrors)
+	}
+	return nil
+}
+
+// readVectorLen reads the uint32 length prefix of a slice encoded by the Encoder or by fr.Vector.WriteTo
+func readVectorLen(r io.ReaderAt) (int, error) {
+	var buf [4]byte
+	if _, err := r.ReadAt(buf[:], 0); err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(buf[:])), nil
+}
+
+// bestCReader returns the window size for the out-of-core MultiExp on nbPoints points.
+// The buckets are kept in memory across chunks, so the cost of the reduction is paid only once.
+func bestCReader(nbPoints int) uint64 {
+	// digits are stored on 16 bits, and the last window may be larger than c
+	implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	var C uint64
+	min := math.MaxFloat64
+	for _, c := range implementedCs {
+		cc := (fr.Bits + 1) * (nbPoints + (1 << c))
+		cost := float64(cc) / float64(c)
+		if cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
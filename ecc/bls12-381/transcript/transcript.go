@@ -0,0 +1,149 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package transcript
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// challengeExtraBytes are the bytes squeezed in addition to fr.Bytes per scalar challenge in hash mode,
+// so that the modular reduction is statistically close to uniform
+const challengeExtraBytes = 16
+
+// Transcript is a Fiat-Shamir transcript. Values are appended under a label, and challenges can be computed at
+// any time; each challenge depends on all the values appended and challenges computed before it.
+type Transcript struct {
+	duplex fiatshamir.Duplex
+	sponge *fiatshamir.Sponge // nil in hash mode
+}
+
+type config struct {
+	h hash.Hash
+}
+
+// Option configures New
+type Option func(*config)
+
+// WithHash sets the hash function used by New (SHA-256 by default)
+func WithHash(h hash.Hash) Option {
+	return func(c *config) {
+		c.h = h
+	}
+}
+
+// New returns a hash based transcript, separated from other protocols by domain.
+// Scalar challenges are obtained by reducing fr.Bytes+16 squeezed bytes modulo r.
+func New(domain string, options ...Option) *Transcript {
+	c := config{h: sha256.New()}
+	for _, opt := range options {
+		opt(&c)
+	}
+	return &Transcript{duplex: fiatshamir.NewHashDuplex(c.h, domain)}
+}
+
+// NewSponge returns a transcript built on a MiMC duplex sponge, separated from other protocols by domain.
+// Scalars are absorbed and squeezed as single field elements, so that verifying a proof in a circuit over fr
+// costs one MiMC compression per scalar, plus one per label. Labels are limited to fr.Bytes-6 bytes.
+func NewSponge(domain string) (*Transcript, error) {
+	s, err := fiatshamir.NewSponge(mimc.NewMiMC(), domain)
+	if err != nil {
+		return nil, err
+	}
+	return &Transcript{duplex: s, sponge: s}, nil
+}
+
+// AppendBytes appends raw bytes to the transcript
+func (t *Transcript) AppendBytes(label string, b []byte) error {
+	return t.duplex.Absorb(label, b)
+}
+
+// AppendScalar appends a scalar to the transcript
+func (t *Transcript) AppendScalar(label string, s *fr.Element) error {
+	return t.AppendScalars(label, *s)
+}
+
+// AppendScalars appends a list of scalars to the transcript, under a single label
+func (t *Transcript) AppendScalars(label string, s ...fr.Element) error {
+	buf := make([]byte, 0, len(s)*fr.Bytes)
+	for i := range s {
+		b := s[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+	if t.sponge != nil {
+		return t.sponge.AbsorbElements(label, buf)
+	}
+	return t.duplex.Absorb(label, buf)
+}
+
+// AppendG1 appends the uncompressed encoding of a G1 point to the transcript
+func (t *Transcript) AppendG1(label string, p *bls12381.G1Affine) error {
+	b := p.RawBytes()
+	return t.duplex.Absorb(label, b[:])
+}
+
+// AppendG2 appends the uncompressed encoding of a G2 point to the transcript
+func (t *Transcript) AppendG2(label string, p *bls12381.G2Affine) error {
+	b := p.RawBytes()
+	return t.duplex.Absorb(label, b[:])
+}
+
+// ChallengeBytes returns n bytes of challenge
+func (t *Transcript) ChallengeBytes(label string, n int) ([]byte, error) {
+	return t.duplex.Squeeze(label, n)
+}
+
+// ChallengeScalar returns a scalar challenge
+func (t *Transcript) ChallengeScalar(label string) (fr.Element, error) {
+	res, err := t.ChallengeScalars(label, 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return res[0], nil
+}
+
+// ChallengeScalars returns n scalar challenges, under a single label
+func (t *Transcript) ChallengeScalars(label string, n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	if t.sponge != nil {
+		b, err := t.sponge.SqueezeElements(label, n)
+		if err != nil {
+			return nil, err
+		}
+		for i := range res {
+			if err = res[i].SetBytesCanonical(b[i*fr.Bytes : (i+1)*fr.Bytes]); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	const size = fr.Bytes + challengeExtraBytes
+	b, err := t.duplex.Squeeze(label, n*size)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].SetBytes(b[i*size : (i+1)*size])
+	}
+	return res, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+func TestFFTFourStep(t *testing.T) {
+	for _, size := range []uint64{1 << 3, 1 << 5, 1 << 8} {
+		for _, opts := range [][]DomainOption{nil, {WithoutPrecompute()}} {
+			domain := NewDomain(size, opts...)
+			for _, decimation := range []Decimation{DIF, DIT} {
+				for _, coset := range []bool{false, true} {
+					for _, inverse := range []bool{false, true} {
+						pol := make([]fr.Element, size)
+						for i := range pol {
+							pol[i].SetRandom()
+						}
+						expected := make([]fr.Element, size)
+						copy(expected, pol)
+
+						var fftOpts []Option
+						if coset {
+							fftOpts = append(fftOpts, OnCoset())
+						}
+						if inverse {
+							domain.FFTInverse(expected, decimation, fftOpts...)
+							domain.FFTInverse(pol, decimation, append(fftOpts, WithFourStep())...)
+						} else {
+							domain.FFT(expected, decimation, fftOpts...)
+							domain.FFT(pol, decimation, append(fftOpts, WithFourStep())...)
+						}
+						for i := range pol {
+							if !pol[i].Equal(&expected[i]) {
+								t.Fatalf("n=%d decimation=%d coset=%v inverse=%v: four-step FFT differs from the reference at %d",
+									size, decimation, coset, inverse, i)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkFFTFourStep(b *testing.B) {
+	const maxSize = 1 << 22
+
+	pol := make([]fr.Element, maxSize)
+	pol[0].SetRandom()
+	for i := 1; i < maxSize; i++ {
+		pol[i] = pol[i-1]
+	}
+
+	for _, i := range []int{18, 20, 22} {
+		sizeDomain := 1 << i
+		domain := NewDomain(uint64(sizeDomain))
+		b.Run("fft 2**"+strconv.Itoa(i)+"bits", func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol[:sizeDomain], DIF)
+			}
+		})
+		b.Run("fft 2**"+strconv.Itoa(i)+"bits (four-step)", func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol[:sizeDomain], DIF, WithFourStep())
+			}
+		})
+	}
+}
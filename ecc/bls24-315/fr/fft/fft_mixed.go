@@ -0,0 +1,389 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// maxRadix is the largest prime radix handled by the mixed radix FFT.
+// Domains whose cardinality has a larger prime factor use Bluestein's algorithm.
+const maxRadix = 64
+
+// smallFactors lists the prime factors p ⩽ maxRadix of r-1 = |𝔽ᵣˣ| with their multiplicity.
+var smallFactors = [][2]uint64{
+	{2, 22},
+	{3, 2},
+	{5, 2},
+	{11, 1},
+	{13, 1},
+	{19, 1},
+	{29, 4},
+	{31, 1},
+}
+
+// bluestein holds the precomputed data for Bluestein's algorithm on a domain of size n.
+type bluestein struct {
+	// inner is a power of 2 domain of size ⩾ 2n-1 used for the convolution
+	inner *Domain
+
+	// chirp (resp. chirpInv) is the FFT (DIF, bit reversed) of ωᵗ⁽ᵗ⁻¹⁾ᐟ² for t < 2n-1,
+	// ω = Generator (resp. GeneratorInv)
+	chirp, chirpInv []fr.Element
+}
+
+// isPowerOfTwo returns true if n is a power of 2
+func isPowerOfTwo(n uint64) bool {
+	return n&(n-1) == 0
+}
+
+// radixFactors returns the prime factors of n (with multiplicity) in increasing order,
+// and true if they are all smaller or equal to maxRadix.
+func radixFactors(n uint64) ([]uint64, bool) {
+	var factors []uint64
+	for _, f := range smallFactors {
+		for n%f[0] == 0 {
+			factors = append(factors, f[0])
+			n /= f[0]
+		}
+	}
+	return factors, n == 1
+}
+
+// smallestSmoothCardinality returns the smallest n ⩾ m such that n divides r-1
+// and all the prime factors of n are smaller or equal to maxRadix.
+func smallestSmoothCardinality(m uint64) (uint64, error) {
+	if m <= 1 {
+		return 1, nil
+	}
+	best := uint64(0)
+	var search func(i int, n uint64)
+	search = func(i int, n uint64) {
+		if n >= m {
+			if best == 0 || n < best {
+				best = n
+			}
+			return
+		}
+		if i == len(smallFactors) {
+			return
+		}
+		p, e := smallFactors[i][0], smallFactors[i][1]
+		for j := uint64(0); j <= e; j++ {
+			search(i+1, n)
+			hi, lo := bits.Mul64(n, p)
+			if hi != 0 {
+				return
+			}
+			n = lo
+		}
+	}
+	search(0, 1)
+	if best == 0 {
+		return 0, fmt.Errorf("m (%d) is too big: no smooth subgroup of 𝔽ᵣˣ of this size", m)
+	}
+	return best, nil
+}
+
+// checkExactCardinality checks that 𝔽ᵣˣ has a subgroup of order n and that an FFT
+// can be performed on it, either with the mixed radix algorithm or with Bluestein's.
+func checkExactCardinality(n uint64) (uint64, error) {
+	if n <= 1 {
+		return 1, nil
+	}
+	if isPowerOfTwo(n) {
+		return n, nil
+	}
+	var rem big.Int
+	rem.Sub(fr.Modulus(), big.NewInt(1)).Mod(&rem, new(big.Int).SetUint64(n))
+	if rem.Sign() != 0 {
+		return 0, fmt.Errorf("n (%d) does not divide r-1: the required root of unity does not exist", n)
+	}
+	if _, smooth := radixFactors(n); !smooth {
+		if _, err := Generator(2*n - 1); err != nil {
+			return 0, errors.New("n is too big for Bluestein's algorithm")
+		}
+	}
+	return n, nil
+}
+
+// rootOfUnity returns a primitive n-th root of unity, n | r-1.
+func rootOfUnity(n uint64) fr.Element {
+	var e big.Int
+	e.Sub(fr.Modulus(), big.NewInt(1)).Div(&e, new(big.Int).SetUint64(n))
+	g := GeneratorFullMultiplicativeGroup()
+	g.Exp(g, &e)
+	return g
+}
+
+func (d *Domain) preComputeMixed() {
+	n := int(d.Cardinality)
+	d.twiddles = [][]fr.Element{make([]fr.Element, n)}
+	d.twiddlesInv = [][]fr.Element{make([]fr.Element, n)}
+	d.cosetTable = make([]fr.Element, n)
+	d.cosetTableInv = make([]fr.Element, n)
+
+	var wg sync.WaitGroup
+	expTable := func(w fr.Element, t []fr.Element) {
+		BuildExpTable(w, t)
+		wg.Done()
+	}
+	wg.Add(4)
+	go expTable(d.Generator, d.twiddles[0])
+	go expTable(d.GeneratorInv, d.twiddlesInv[0])
+	go expTable(d.FrMultiplicativeGen, d.cosetTable)
+	go expTable(d.FrMultiplicativeGenInv, d.cosetTableInv)
+	wg.Wait()
+
+	if _, smooth := radixFactors(d.Cardinality); smooth {
+		d.subDomain = d.newSubDomain(true)
+	} else {
+		d.bluestein = newBluestein(d.twiddles[0], d.twiddlesInv[0])
+	}
+}
+
+// newSubDomain returns the domain of size 2ᵃ, the largest power of 2 dividing the cardinality,
+// generated by Generatorⁿᐟ²ᵃ. It computes the power of 2 sub transforms of the mixed radix FFT.
+func (d *Domain) newSubDomain(withPrecompute bool) *Domain {
+	m := uint64(1) << bits.TrailingZeros64(d.Cardinality)
+	var w fr.Element
+	w.Exp(d.Generator, new(big.Int).SetUint64(d.Cardinality/m))
+	return newPowerOfTwoDomain(m, w, withPrecompute)
+}
+
+// newPowerOfTwoDomain returns a domain of cardinality m (a power of 2) generated by w,
+// without coset tables.
+func newPowerOfTwoDomain(m uint64, w fr.Element, withPrecompute bool) *Domain {
+	d := &Domain{Cardinality: m, Generator: w, withPrecompute: withPrecompute}
+	d.GeneratorInv.Inverse(&d.Generator)
+	d.CardinalityInv.SetUint64(m).Inverse(&d.CardinalityInv)
+	d.FrMultiplicativeGen.SetOne()
+	d.FrMultiplicativeGenInv.SetOne()
+	if withPrecompute {
+		nbStages := uint64(bits.TrailingZeros64(m))
+		d.twiddles = make([][]fr.Element, nbStages)
+		d.twiddlesInv = make([][]fr.Element, nbStages)
+		buildTwiddles(d.twiddles, d.Generator, nbStages)
+		buildTwiddles(d.twiddlesInv, d.GeneratorInv, nbStages)
+	}
+	return d
+}
+
+// newBluestein precomputes the convolution kernels for a domain of size n = len(table),
+// where table (resp. tableInv) holds the powers of the generator (resp. its inverse).
+func newBluestein(table, tableInv []fr.Element) *bluestein {
+	n := uint64(len(table))
+	b := &bluestein{inner: NewDomain(2*n - 1)}
+	b.chirp = b.kernel(table)
+	b.chirpInv = b.kernel(tableInv)
+	return b
+}
+
+// kernel returns FFT(ωᵗ⁽ᵗ⁻¹⁾ᐟ²)_{t < 2n-1} in bit reversed order, where table[i] = ωⁱ.
+func (b *bluestein) kernel(table []fr.Element) []fr.Element {
+	n := uint64(len(table))
+	res := make([]fr.Element, b.inner.Cardinality)
+	e := uint64(0) // t(t-1)/2 mod n
+	for t := uint64(0); t < 2*n-1; t++ {
+		res[t] = table[e]
+		e = (e + t) % n
+	}
+	b.inner.FFT(res, DIF)
+	return res
+}
+
+// fftMixed computes the (inverse) FFT of a on a domain which cardinality is not a power of 2.
+// Input and output are in natural order.
+func (domain *Domain) fftMixed(a []fr.Element, inverse bool, opt fftConfig) {
+	n := int(domain.Cardinality)
+	if len(a) != n {
+		panic("fft: len(a) must be equal to the domain cardinality")
+	}
+
+	var table, coset []fr.Element
+	if domain.withPrecompute {
+		if inverse {
+			table, coset = domain.twiddlesInv[0], domain.cosetTableInv
+		} else {
+			table, coset = domain.twiddles[0], domain.cosetTable
+		}
+	} else {
+		w, u := domain.Generator, domain.FrMultiplicativeGen
+		if inverse {
+			w, u = domain.GeneratorInv, domain.FrMultiplicativeGenInv
+		}
+		table = make([]fr.Element, n)
+		BuildExpTable(w, table)
+		if opt.coset {
+			coset = make([]fr.Element, n)
+			BuildExpTable(u, coset)
+		}
+	}
+
+	if opt.coset && !inverse {
+		parallel.Execute(n, func(start, end int) {
+			for i := start; i < end; i++ {
+				a[i].Mul(&a[i], &coset[i])
+			}
+		}, opt.nbTasks)
+	}
+
+	scale := domain.CardinalityInv
+	if factors, smooth := radixFactors(domain.Cardinality); smooth {
+		sub := domain.subDomain
+		if sub == nil {
+			sub = domain.newSubDomain(false)
+		}
+		// the power of 2 sub transforms are done by sub, the odd factors by mixedRadixFFT
+		subFFT := func(s []fr.Element) {
+			sub.FFT(s, DIT, WithNbTasks(opt.nbTasks))
+		}
+		if inverse {
+			// sub.FFTInverse already scales by 1/2ᵃ
+			subFFT = func(s []fr.Element) {
+				sub.FFTInverse(s, DIT, WithNbTasks(opt.nbTasks))
+			}
+			var m fr.Element
+			m.SetUint64(sub.Cardinality)
+			scale.Mul(&scale, &m)
+		}
+		in := make([]fr.Element, n)
+		copy(in, a)
+		mixedRadixFFT(a, in, 1, table, factors[bits.TrailingZeros64(domain.Cardinality):], subFFT, opt.nbTasks)
+	} else {
+		b := domain.bluestein
+		if b == nil {
+			b = &bluestein{inner: NewDomain(uint64(2*n - 1))}
+			b.chirp = b.kernel(table)
+		} else if inverse {
+			b = &bluestein{inner: b.inner, chirp: b.chirpInv}
+		}
+		b.fft(a, table, opt.nbTasks)
+	}
+
+	if !inverse {
+		return
+	}
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].Mul(&a[i], &scale)
+			if opt.coset {
+				a[i].Mul(&a[i], &coset[i])
+			}
+		}
+	}, opt.nbTasks)
+}
+
+// mixedRadixFFT computes out[k] = ∑ⱼ in[j*stride]ωʲᵏ for k < len(out), where ω is
+// a primitive len(out)-th root of unity taken from table (table[i] = gⁱ, ω = g^(len(table)/len(out))).
+// factors are the odd prime factors of len(out); the remaining power of 2 transforms,
+// of length 2ᵃ, are computed in place by subFFT from inputs in bit reversed order.
+func mixedRadixFFT(out, in []fr.Element, stride int, table []fr.Element, factors []uint64, subFFT func([]fr.Element), nbTasks int) {
+	n := len(out)
+	if len(factors) == 0 {
+		nn := uint64(64 - bits.TrailingZeros64(uint64(n)))
+		for l := 0; l < n; l++ {
+			out[bits.Reverse64(uint64(l))>>nn] = in[l*stride]
+		}
+		if n > 1 {
+			subFFT(out)
+		}
+		return
+	}
+	p := int(factors[len(factors)-1])
+	m := n / p
+
+	// p sub transforms of size m, on the decimated inputs
+	for j := 0; j < p; j++ {
+		mixedRadixFFT(out[j*m:(j+1)*m], in[j*stride:], stride*p, table, factors[:len(factors)-1], subFFT, nbTasks)
+	}
+
+	// butterflies of radix p
+	s := len(table) / n // ωⁱ = table[i*s]
+	butterflies := func(start, end int) {
+		t := make([]fr.Element, p)
+		var acc, tmp fr.Element
+		for k := start; k < end; k++ {
+			t[0] = out[k]
+			for j := 1; j < p; j++ {
+				t[j].Mul(&out[j*m+k], &table[j*k*s])
+			}
+			if p == 3 {
+				// ω₃² = -1-ω₃
+				tmp.Sub(&t[1], &t[2]).Mul(&tmp, &table[m*s])
+				out[k].Add(&t[0], &t[1]).Add(&out[k], &t[2])
+				out[k+m].Sub(&t[0], &t[2]).Add(&out[k+m], &tmp)
+				out[k+2*m].Sub(&t[0], &t[1]).Sub(&out[k+2*m], &tmp)
+				continue
+			}
+			for q := 0; q < p; q++ {
+				acc = t[0]
+				for j := 1; j < p; j++ {
+					tmp.Mul(&t[j], &table[((j*q)%p)*m*s])
+					acc.Add(&acc, &tmp)
+				}
+				out[k+q*m] = acc
+			}
+		}
+	}
+	if m >= butterflyThreshold {
+		parallel.Execute(m, butterflies, nbTasks)
+	} else {
+		butterflies(0, m)
+	}
+}
+
+// fft computes a[k] = ∑ⱼ a[j]ωʲᵏ with Bluestein's algorithm, writing jk = t(k+j) - t(k) - t(j) with t(x) = x(x-1)/2,
+// so that the transform becomes a convolution with the chirp ωᵗ⁽ˣ⁾, computed with power of 2 FFTs.
+// table[i] = ωⁱ and b.chirp must correspond to the same ω.
+func (b *bluestein) fft(a []fr.Element, table []fr.Element, nbTasks int) {
+	n := uint64(len(a))
+	// tInv[x] = ω^(-t(x))
+	tInv := func(x uint64) *fr.Element {
+		e := (x * (x - 1) / 2) % n
+		return &table[(n-e)%n]
+	}
+
+	buf := make([]fr.Element, b.inner.Cardinality)
+	parallel.Execute(int(n), func(start, end int) {
+		for j := uint64(start); j < uint64(end); j++ {
+			buf[n-1-j].Mul(&a[j], tInv(j))
+		}
+	}, nbTasks)
+
+	b.inner.FFT(buf, DIF, WithNbTasks(nbTasks))
+	parallel.Execute(len(buf), func(start, end int) {
+		for i := start; i < end; i++ {
+			buf[i].Mul(&buf[i], &b.chirp[i])
+		}
+	}, nbTasks)
+	b.inner.FFTInverse(buf, DIT, WithNbTasks(nbTasks))
+
+	parallel.Execute(int(n), func(start, end int) {
+		for k := uint64(start); k < uint64(end); k++ {
+			a[k].Mul(&buf[n-1+k], tInv(k))
+		}
+	}, nbTasks)
+}
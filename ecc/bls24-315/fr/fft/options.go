@@ -28,8 +28,10 @@ import (
 type Option func(*fftConfig)
 
 type fftConfig struct {
-	coset   bool
-	nbTasks int
+	coset     bool
+	nbTasks   int
+	chunkSize int
+	fourStep  bool
 }
 
 // OnCoset if provided, FFT(a) returns the evaluation of a on a coset.
@@ -51,12 +53,34 @@ func WithNbTasks(nbTasks int) Option {
 	}
 }
 
+// WithFourStep selects the four-step (Bailey) FFT: the transform is split in FFTs of size ~√n on
+// contiguous rows, separated by cache-blocked transpositions, with the bit reversal and coset scaling fused
+// into the passes. It uses n extra elements of memory; whether it beats the recursive kernels depends
+// on the cache hierarchy, see BenchmarkFFTFourStep. It only applies to power of 2 domains.
+func WithFourStep() Option {
+	return func(opt *fftConfig) {
+		opt.fourStep = true
+	}
+}
+
+// WithChunkSize sets the number of elements held in memory by the out-of-core FFTs
+// (FFTReaderAt, FFTInverseReaderAt). Default is 1 << 22.
+func WithChunkSize(nbElements int) Option {
+	if nbElements < 1 {
+		nbElements = 1
+	}
+	return func(opt *fftConfig) {
+		opt.chunkSize = nbElements
+	}
+}
+
 // default options
 func fftOptions(opts ...Option) fftConfig {
 	// apply options
 	opt := fftConfig{
-		coset:   false,
-		nbTasks: runtime.NumCPU(),
+		coset:     false,
+		nbTasks:   runtime.NumCPU(),
+		chunkSize: 1 << 22,
 	}
 	for _, option := range opts {
 		option(&opt)
@@ -72,8 +96,18 @@ type DomainOption func(*domainConfig)
 type domainConfig struct {
 	shift          *fr.Element
 	withPrecompute bool
+	cardinality    cardinalityPolicy
 }
 
+// cardinalityPolicy defines how the cardinality of a domain is derived from the requested size
+type cardinalityPolicy uint8
+
+const (
+	powerOfTwoCardinality cardinalityPolicy = iota
+	smoothCardinality
+	exactCardinality
+)
+
 // WithShift sets the FrMultiplicativeGen of the domain.
 // Default is generator of the largest 2-adic subgroup.
 func WithShift(shift fr.Element) DomainOption {
@@ -90,6 +124,24 @@ func WithoutPrecompute() DomainOption {
 	}
 }
 
+// WithSmoothCardinality sets the cardinality of the domain to the smallest n ⩾ m
+// such that n divides r-1 and has only small prime factors (2, 3, ...).
+// For instance, m = 3·2ᵏ is not rounded up to 2ᵏ⁺².
+// FFTs on such domains use a mixed radix algorithm; inputs and outputs are in natural order.
+func WithSmoothCardinality() DomainOption {
+	return func(opt *domainConfig) {
+		opt.cardinality = smoothCardinality
+	}
+}
+
+// WithExactCardinality sets the cardinality of the domain to m, which must divide r-1.
+// If m has a large prime factor, FFTs use Bluestein's algorithm; inputs and outputs are in natural order.
+func WithExactCardinality() DomainOption {
+	return func(opt *domainConfig) {
+		opt.cardinality = exactCardinality
+	}
+}
+
 // default options
 func domainOptions(opts ...DomainOption) domainConfig {
 	// apply options
@@ -0,0 +1,536 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package layered
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// Unlike the data-parallel gkr package, where a small circuit is repeated over many instances, this package proves
+// the evaluation of a single layered arithmetic circuit with arbitrary wiring.
+//
+// The value Vᵢ of the i-th layer is the multilinear extension of the values of its gates:
+//
+//	Vᵢ(z) = ∑ₓ,ᵧ addᵢ(z, x, y)·(Vᵢ₋₁(x) + Vᵢ₋₁(y)) + mulᵢ(z, x, y)·Vᵢ₋₁(x)·Vᵢ₋₁(y)
+//
+// where addᵢ and mulᵢ are the (sparse) wiring predicates. A claim on Vᵢ is reduced to two claims on Vᵢ₋₁
+// with a sumcheck over (x, y), run in two phases in time linear in the number of gates (Libra, https://eprint.iacr.org/2019/317).
+// The two claims are combined with a random coefficient into a single claim for the next layer.
+//
+// The last sumcheck leaves two claims on the multilinear extension of the inputs. With Prove and Verify, the inputs
+// are public: they are bound to the transcript along with the circuit and the outputs, and the verifier evaluates
+// their multilinear extension itself. With ProveCommitted and VerifyCommitted, the verifier doesn't see the inputs:
+// the caller binds a multilinear commitment to them through the BaseChallenges of the transcript settings, and checks
+// the returned InputClaims with openings of that commitment.
+
+// GateType is the operation performed by a gate
+type GateType uint8
+
+const (
+	Add GateType = iota
+	Mul
+)
+
+// Gate computes Left + Right or Left × Right, where Left and Right are the indexes of gates of the previous layer,
+// or of inputs for the first layer.
+type Gate struct {
+	Type        GateType
+	Left, Right int
+}
+
+// Layer is a list of gates reading the previous layer
+type Layer []Gate
+
+// Circuit is a layered arithmetic circuit. Layers[0] reads the NbInputs inputs, and the last layer is the output.
+type Circuit struct {
+	NbInputs int
+	Layers   []Layer
+}
+
+// InputClaim is a claimed evaluation at Point of the multilinear extension of the inputs, padded with zeroes
+// to a power of 2
+type InputClaim struct {
+	Point      []fr.Element
+	Evaluation fr.Element
+}
+
+// LayerProof is the proof for one layer: the sumcheck over the left inputs then the right inputs of the gates,
+// and the claimed evaluations of the previous layer at the resulting points.
+type LayerProof struct {
+	PartialSumPolys []polynomial.Polynomial // evaluations at 1 and 2 of the (quadratic) round polynomials
+	Left, Right     fr.Element
+}
+
+// Proof has one LayerProof per layer, from the output layer down to the first one
+type Proof []LayerProof
+
+// check returns an error if the circuit is malformed
+func (c *Circuit) check() error {
+	if c.NbInputs < 1 {
+		return errors.New("the circuit must have at least one input")
+	}
+	if len(c.Layers) == 0 {
+		return errors.New("the circuit must have at least one layer")
+	}
+	prevSize := c.NbInputs
+	for i, layer := range c.Layers {
+		if len(layer) == 0 {
+			return fmt.Errorf("layer %d is empty", i)
+		}
+		for j, g := range layer {
+			if g.Type != Add && g.Type != Mul {
+				return fmt.Errorf("layer %d, gate %d: unknown gate type %d", i, j, g.Type)
+			}
+			if g.Left < 0 || g.Left >= prevSize || g.Right < 0 || g.Right >= prevSize {
+				return fmt.Errorf("layer %d, gate %d: input out of range", i, j)
+			}
+		}
+		prevSize = len(layer)
+	}
+	return nil
+}
+
+// Evaluate returns the values of the gates of all the layers; res[0] are the inputs and res[len(c.Layers)] the outputs.
+func (c *Circuit) Evaluate(inputs []fr.Element) ([][]fr.Element, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	if len(inputs) != c.NbInputs {
+		return nil, fmt.Errorf("expected %d inputs, got %d", c.NbInputs, len(inputs))
+	}
+	res := make([][]fr.Element, len(c.Layers)+1)
+	res[0] = inputs
+	for i, layer := range c.Layers {
+		prev := res[i]
+		values := make([]fr.Element, len(layer))
+		for j, g := range layer {
+			if g.Type == Add {
+				values[j].Add(&prev[g.Left], &prev[g.Right])
+			} else {
+				values[j].Mul(&prev[g.Left], &prev[g.Right])
+			}
+		}
+		res[i+1] = values
+	}
+	return res, nil
+}
+
+// ChallengeNames returns the names of the Fiat-Shamir challenges of a proof for c,
+// for use with a transcript provided through fiatshamir.WithTranscript.
+func ChallengeNames(c *Circuit, prefix string) []string {
+	var names []string
+	for j := 0; j < logSize(len(c.Layers[len(c.Layers)-1])); j++ {
+		names = append(names, prefix+"out."+strconv.Itoa(j))
+	}
+	for i := len(c.Layers) - 1; i >= 0; i-- {
+		layerPrefix := prefix + "l" + strconv.Itoa(i) + "."
+		if i != len(c.Layers)-1 {
+			names = append(names, layerPrefix+"comb")
+		}
+		b := logSize(c.prevSize(i))
+		for j := 0; j < b; j++ {
+			names = append(names, layerPrefix+"x"+strconv.Itoa(j))
+		}
+		for j := 0; j < b; j++ {
+			names = append(names, layerPrefix+"y"+strconv.Itoa(j))
+		}
+	}
+	return names
+}
+
+var errNoCommitment = errors.New("the commitment to the inputs must be bound through the BaseChallenges of the transcript settings")
+
+// Prove proves that the outputs of c are the evaluation of c on inputs
+func Prove(c *Circuit, inputs []fr.Element, transcriptSettings fiatshamir.Settings) (Proof, error) {
+	proof, _, err := prove(c, inputs, inputs, transcriptSettings)
+	return proof, err
+}
+
+// ProveCommitted proves that the outputs of c are the evaluation of c on inputs, given by a multilinear commitment
+// that must be bound through transcriptSettings.BaseChallenges. The inputs aren't bound to the transcript.
+// It returns the two claims on the inputs to be proven with openings of the commitment.
+func ProveCommitted(c *Circuit, inputs []fr.Element, transcriptSettings fiatshamir.Settings) (Proof, [2]InputClaim, error) {
+	if len(transcriptSettings.BaseChallenges) == 0 {
+		return nil, [2]InputClaim{}, errNoCommitment
+	}
+	return prove(c, inputs, nil, transcriptSettings)
+}
+
+// prove runs the prover, binding boundInputs to the transcript
+func prove(c *Circuit, inputs, boundInputs []fr.Element, transcriptSettings fiatshamir.Settings) (Proof, [2]InputClaim, error) {
+	var claims [2]InputClaim
+	values, err := c.Evaluate(inputs)
+	if err != nil {
+		return nil, claims, err
+	}
+	t, err := newTranscript(c, boundInputs, values[len(c.Layers)], transcriptSettings)
+	if err != nil {
+		return nil, claims, err
+	}
+
+	proof := make(Proof, len(c.Layers))
+	g, err := t.challenges(logSize(len(c.Layers[len(c.Layers)-1])), nil)
+	if err != nil {
+		return nil, claims, err
+	}
+	G := eqTable(g, nil, fr.Element{})
+
+	for i := len(c.Layers) - 1; i >= 0; i-- {
+		layer := c.Layers[i]
+		v := padded(values[i])
+		b := v.NumVars()
+
+		// phase 1: ∑ₓ V(x)·A₁(x) + A₂(x), where
+		// A₁(x) = ∑_z,y G(z)·(add(z, x, y) + mul(z, x, y)·V(y)) and A₂(x) = ∑_z,y G(z)·add(z, x, y)·V(y)
+		a1 := make(polynomial.MultiLin, len(v))
+		a2 := make(polynomial.MultiLin, len(v))
+		var t_ fr.Element
+		for z, gate := range layer {
+			if gate.Type == Mul {
+				t_.Mul(&G[z], &v[gate.Right])
+				a1[gate.Left].Add(&a1[gate.Left], &t_)
+			} else {
+				a1[gate.Left].Add(&a1[gate.Left], &G[z])
+				t_.Mul(&G[z], &v[gate.Right])
+				a2[gate.Left].Add(&a2[gate.Left], &t_)
+			}
+		}
+		vx := v.Clone()
+		rx, polys, err := t.sumcheckProve(vx, a1, a2, b, nil)
+		if err != nil {
+			return nil, claims, err
+		}
+		proof[i].Left = vx[0]
+
+		// phase 2: with x = rₓ, ∑ᵧ V(y)·B₁(y) + B₂(y), where
+		// B₁(y) = ∑_z G(z)·eq(rₓ, x)·(add(z, x, y) + mul(z, x, y)·V(rₓ)) and B₂(y) = ∑_z G(z)·eq(rₓ, x)·add(z, x, y)·V(rₓ)
+		ex := eqTable(rx, nil, fr.Element{})
+		b1 := make(polynomial.MultiLin, len(v))
+		b2 := make(polynomial.MultiLin, len(v))
+		var e fr.Element
+		for z, gate := range layer {
+			e.Mul(&G[z], &ex[gate.Left])
+			if gate.Type == Mul {
+				t_.Mul(&e, &proof[i].Left)
+				b1[gate.Right].Add(&b1[gate.Right], &t_)
+			} else {
+				b1[gate.Right].Add(&b1[gate.Right], &e)
+				t_.Mul(&e, &proof[i].Left)
+				b2[gate.Right].Add(&b2[gate.Right], &t_)
+			}
+		}
+		vy := v
+		var ry []fr.Element
+		if ry, polys, err = t.sumcheckProve(vy, b1, b2, b, polys); err != nil {
+			return nil, claims, err
+		}
+		proof[i].Right = vy[0]
+		proof[i].PartialSumPolys = polys
+
+		if i == 0 {
+			claims[0] = InputClaim{Point: rx, Evaluation: proof[i].Left}
+			claims[1] = InputClaim{Point: ry, Evaluation: proof[i].Right}
+			break
+		}
+		// next claim: Vᵢ₋₁(rₓ) + β·Vᵢ₋₁(r_y)
+		beta, err := t.next(proof[i].Left, proof[i].Right)
+		if err != nil {
+			return nil, claims, err
+		}
+		G = eqTable(rx, ry, beta)
+	}
+
+	return proof, claims, nil
+}
+
+// Verify checks that outputs are the evaluation of c on inputs
+func Verify(c *Circuit, inputs, outputs []fr.Element, proof Proof, transcriptSettings fiatshamir.Settings) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	if len(inputs) != c.NbInputs {
+		return fmt.Errorf("expected %d inputs, got %d", c.NbInputs, len(inputs))
+	}
+	claims, err := verify(c, inputs, outputs, proof, transcriptSettings)
+	if err != nil {
+		return err
+	}
+	in := padded(inputs)
+	for i := range claims {
+		if expected := in.Evaluate(claims[i].Point, nil); !expected.Equal(&claims[i].Evaluation) {
+			return errors.New("incorrect input evaluation")
+		}
+	}
+	return nil
+}
+
+// VerifyCommitted checks that outputs are the evaluation of c on inputs given by a multilinear commitment,
+// which must be bound through transcriptSettings.BaseChallenges. It returns the two claims on the inputs,
+// which the caller must check with openings of the commitment.
+func VerifyCommitted(c *Circuit, outputs []fr.Element, proof Proof, transcriptSettings fiatshamir.Settings) ([2]InputClaim, error) {
+	if len(transcriptSettings.BaseChallenges) == 0 {
+		return [2]InputClaim{}, errNoCommitment
+	}
+	if err := c.check(); err != nil {
+		return [2]InputClaim{}, err
+	}
+	return verify(c, nil, outputs, proof, transcriptSettings)
+}
+
+// verify checks the proof down to the inputs, binding boundInputs to the transcript, and returns the claims on the inputs
+func verify(c *Circuit, boundInputs, outputs []fr.Element, proof Proof, transcriptSettings fiatshamir.Settings) ([2]InputClaim, error) {
+	var claims [2]InputClaim
+	if len(outputs) != len(c.Layers[len(c.Layers)-1]) {
+		return claims, fmt.Errorf("expected %d outputs, got %d", len(c.Layers[len(c.Layers)-1]), len(outputs))
+	}
+	if len(proof) != len(c.Layers) {
+		return claims, errors.New("malformed proof: wrong number of layers")
+	}
+
+	t, err := newTranscript(c, boundInputs, outputs, transcriptSettings)
+	if err != nil {
+		return claims, err
+	}
+	g, err := t.challenges(logSize(len(outputs)), nil)
+	if err != nil {
+		return claims, err
+	}
+	claim := padded(outputs).Evaluate(g, nil)
+	G := eqTable(g, nil, fr.Element{})
+
+	for i := len(c.Layers) - 1; i >= 0; i-- {
+		p := &proof[i]
+		b := logSize(c.prevSize(i))
+		if len(p.PartialSumPolys) != 2*b {
+			return claims, fmt.Errorf("malformed proof: layer %d has %d rounds, expected %d", i, len(p.PartialSumPolys), 2*b)
+		}
+
+		r := make([]fr.Element, 2*b)
+		for j := range r {
+			gJ := p.PartialSumPolys[j]
+			if len(gJ) != 2 {
+				return claims, errors.New("malformed proof: round polynomials must have 2 evaluations")
+			}
+			if r[j], err = t.next(gJ...); err != nil {
+				return claims, err
+			}
+			// gⱼ(0) + gⱼ(1) = gⱼ₋₁(rⱼ₋₁)
+			var g0 fr.Element
+			g0.Sub(&claim, &gJ[0])
+			gJCoeffs := polynomial.InterpolateOnRange([]fr.Element{g0, gJ[0], gJ[1]})
+			claim = gJCoeffs.Eval(&r[j])
+		}
+		rx, ry := r[:b], r[b:]
+
+		// wiring predicates at (G, rₓ, r_y)
+		ex := eqTable(rx, nil, fr.Element{})
+		ey := eqTable(ry, nil, fr.Element{})
+		var add, mul, e fr.Element
+		for z, gate := range c.Layers[i] {
+			e.Mul(&G[z], &ex[gate.Left]).Mul(&e, &ey[gate.Right])
+			if gate.Type == Mul {
+				mul.Add(&mul, &e)
+			} else {
+				add.Add(&add, &e)
+			}
+		}
+		var expected, t_ fr.Element
+		expected.Add(&p.Left, &p.Right).Mul(&expected, &add)
+		t_.Mul(&p.Left, &p.Right).Mul(&t_, &mul)
+		expected.Add(&expected, &t_)
+		if !expected.Equal(&claim) {
+			return claims, fmt.Errorf("layer %d: sumcheck final evaluation mismatch", i)
+		}
+
+		if i == 0 {
+			claims[0] = InputClaim{Point: rx, Evaluation: p.Left}
+			claims[1] = InputClaim{Point: ry, Evaluation: p.Right}
+			return claims, nil
+		}
+
+		beta, err := t.next(p.Left, p.Right)
+		if err != nil {
+			return claims, err
+		}
+		claim.Mul(&beta, &p.Right).Add(&claim, &p.Left)
+		G = eqTable(rx, ry, beta)
+	}
+	return claims, nil
+}
+
+// prevSize returns the number of values read by the i-th layer
+func (c *Circuit) prevSize(i int) int {
+	if i == 0 {
+		return c.NbInputs
+	}
+	return len(c.Layers[i-1])
+}
+
+type transcript struct {
+	*fiatshamir.Transcript
+	names []string
+}
+
+// newTranscript sets up the Fiat-Shamir transcript, and binds the circuit, the inputs (if not nil) and the outputs to the first challenge
+func newTranscript(c *Circuit, inputs, outputs []fr.Element, s fiatshamir.Settings) (*transcript, error) {
+	t := &transcript{names: ChallengeNames(c, s.Prefix)}
+	if s.Transcript == nil {
+		t.Transcript = fiatshamir.NewTranscript(s.Hash, t.names...)
+	} else {
+		t.Transcript = s.Transcript
+	}
+	if len(t.names) == 0 {
+		return t, nil
+	}
+	for i := range s.BaseChallenges {
+		if err := t.Bind(t.names[0], s.BaseChallenges[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.Bind(t.names[0], c.bytes()); err != nil {
+		return nil, err
+	}
+	for _, values := range [][]fr.Element{inputs, outputs} {
+		for i := range values {
+			bytes := values[i].Bytes()
+			if err := t.Bind(t.names[0], bytes[:]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return t, nil
+}
+
+// bytes returns an encoding of the circuit: the number of inputs, then the size of each layer followed by its gates
+func (c *Circuit) bytes() []byte {
+	res := binary.BigEndian.AppendUint64(nil, uint64(c.NbInputs))
+	for _, layer := range c.Layers {
+		res = binary.BigEndian.AppendUint64(res, uint64(len(layer)))
+		for _, g := range layer {
+			res = append(res, byte(g.Type))
+			res = binary.BigEndian.AppendUint64(res, uint64(g.Left))
+			res = binary.BigEndian.AppendUint64(res, uint64(g.Right))
+		}
+	}
+	return res
+}
+
+// next binds the given values to the next challenge and computes it
+func (t *transcript) next(bindings ...fr.Element) (fr.Element, error) {
+	var res fr.Element
+	name := t.names[0]
+	for i := range bindings {
+		bytes := bindings[i].Bytes()
+		if err := t.Bind(name, bytes[:]); err != nil {
+			return res, err
+		}
+	}
+	bytes, err := t.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(bytes)
+	t.names = t.names[1:]
+	return res, nil
+}
+
+// challenges computes the n next challenges, binding the given values to the first one
+func (t *transcript) challenges(n int, bindings []fr.Element) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	for i := range res {
+		var err error
+		if res[i], err = t.next(bindings...); err != nil {
+			return nil, err
+		}
+		bindings = nil
+	}
+	return res, nil
+}
+
+// sumcheckProve runs the b rounds of the sumcheck on ∑ V·A + B, folding the tables, and appends the round
+// polynomials to polys. On return, v[0] = V(r).
+func (t *transcript) sumcheckProve(v, a, b polynomial.MultiLin, nbRounds int, polys []polynomial.Polynomial) ([]fr.Element, []polynomial.Polynomial, error) {
+	r := make([]fr.Element, nbRounds)
+	for j := range r {
+		gJ := roundPolynomial(v, a, b)
+		polys = append(polys, gJ)
+		var err error
+		if r[j], err = t.next(gJ...); err != nil {
+			return nil, nil, err
+		}
+		v.Fold(r[j])
+		a.Fold(r[j])
+		b.Fold(r[j])
+	}
+	return r, polys, nil
+}
+
+// roundPolynomial returns the evaluations at 1 and 2 of the first variable of ∑ V·A + B over the hypercube
+// of the other variables
+func roundPolynomial(v, a, b polynomial.MultiLin) polynomial.Polynomial {
+	mid := len(v) / 2
+	res := make(polynomial.Polynomial, 2)
+	var v2, a2, b2, t fr.Element
+	for i := 0; i < mid; i++ {
+		// at 1
+		t.Mul(&v[mid+i], &a[mid+i]).Add(&t, &b[mid+i])
+		res[0].Add(&res[0], &t)
+
+		// at 2: f(2) = 2f(1) - f(0)
+		v2.Double(&v[mid+i]).Sub(&v2, &v[i])
+		a2.Double(&a[mid+i]).Sub(&a2, &a[i])
+		b2.Double(&b[mid+i]).Sub(&b2, &b[i])
+		t.Mul(&v2, &a2).Add(&t, &b2)
+		res[1].Add(&res[1], &t)
+	}
+	return res
+}
+
+// eqTable returns the table of eq(p, ·) + β·eq(q, ·) on the hypercube, or of eq(p, ·) if q is nil
+func eqTable(p, q []fr.Element, beta fr.Element) polynomial.MultiLin {
+	res := make(polynomial.MultiLin, 1<<len(p))
+	res[0].SetOne()
+	res.Eq(p)
+	if q == nil {
+		return res
+	}
+	eqQ := make(polynomial.MultiLin, 1<<len(q))
+	eqQ[0] = beta
+	eqQ.Eq(q)
+	res.Add(res, eqQ)
+	return res
+}
+
+// padded returns values padded with zeroes to a power of 2 length
+func padded(values []fr.Element) polynomial.MultiLin {
+	res := make(polynomial.MultiLin, 1<<logSize(len(values)))
+	copy(res, values)
+	return res
+}
+
+// logSize returns ⌈log₂(n)⌉
+func logSize(n int) int {
+	return bits.Len(uint(n - 1))
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var bigIntPool = sync.Pool{
@@ -624,6 +625,142 @@ func (z *E24) ExpGLV(x E24, k *big.Int) *E24 {
 	return z
 }
 
+// MultiExp sets z to ∏ᵢ basesᵢ^scalarsᵢ (mod q²⁴) and returns it
+// bases must be in GT
+//
+// Each scalar is split with the 2-dimensional GLV decomposition (as in ExpGLV), and the
+// product of powers is computed with a signed-digit bucket method (Pippenger). The windows
+// are processed in parallel and share the cyclotomic squarings.
+func (z *E24) MultiExp(bases []E24, scalars []fr.Element) (*E24, error) {
+	if len(bases) != len(scalars) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	if len(bases) == 0 {
+		return z.SetOne(), nil
+	}
+
+	// split the scalars, xᵏ = x^{k₁} · Frobenius(x)^{k₂} with k₁, k₂ ≥ 0
+	nbPoints := 2 * len(bases)
+	points := make([]E24, nbPoints)
+	k := make([]big.Int, nbPoints)
+	parallel.Execute(len(bases), func(start, end int) {
+		var e big.Int
+		for i := start; i < end; i++ {
+			scalars[i].BigInt(&e)
+			s := ecc.SplitScalar(&e, &glvBasis)
+			points[2*i].Set(&bases[i])
+			points[2*i+1].Frobenius(&bases[i])
+			for j := 0; j < 2; j++ {
+				if s[j].Sign() == -1 {
+					s[j].Neg(&s[j])
+					points[2*i+j].InverseUnitary(&points[2*i+j])
+				}
+				k[2*i+j].Set(&s[j])
+			}
+		}
+	})
+
+	maxBit := 0
+	for i := range k {
+		if k[i].BitLen() > maxBit {
+			maxBit = k[i].BitLen()
+		}
+	}
+	if maxBit == 0 {
+		return z.SetOne(), nil
+	}
+
+	// a window of c bits costs nbPoints multiplications in the buckets
+	// and 2^c multiplications to aggregate the 2^(c-1) buckets
+	c, cost := 1, -1
+	for i := 1; i <= 16; i++ {
+		nbWindows := (maxBit + i) / i
+		if iCost := nbWindows * (nbPoints + (1 << i)); cost == -1 || iCost < cost {
+			c, cost = i, iCost
+		}
+	}
+	nbWindows := (maxBit + c) / c // room for the last carry
+
+	// signed digits in [-2^(c-1), 2^(c-1)]
+	digits := make([]int32, nbPoints*nbWindows)
+	parallel.Execute(nbPoints, func(start, end int) {
+		for i := start; i < end; i++ {
+			carry := int32(0)
+			for w := 0; w < nbWindows; w++ {
+				d := carry
+				for b := 0; b < c; b++ {
+					d += int32(k[i].Bit(w*c+b)) << b
+				}
+				carry = 0
+				if d > 1<<(c-1) {
+					d -= 1 << c
+					carry = 1
+				}
+				digits[i*nbWindows+w] = d
+			}
+		}
+	})
+
+	// windowᵥ = ∏ᵢ pointsᵢ^digitsᵢᵥ
+	windows := make([]E24, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		buckets := make([]E24, 1<<(c-1))
+		used := make([]bool, len(buckets))
+		var p E24
+		for w := start; w < end; w++ {
+			for j := range used {
+				used[j] = false
+			}
+			for i := 0; i < nbPoints; i++ {
+				d := digits[i*nbWindows+w]
+				if d == 0 {
+					continue
+				}
+				if d > 0 {
+					p.Set(&points[i])
+				} else {
+					d = -d
+					p.InverseUnitary(&points[i])
+				}
+				if used[d-1] {
+					buckets[d-1].Mul(&buckets[d-1], &p)
+				} else {
+					buckets[d-1].Set(&p)
+					used[d-1] = true
+				}
+			}
+
+			// ∏ⱼ bucketⱼ^(j+1) with running products
+			var running, sum E24
+			running.SetOne()
+			sum.SetOne()
+			started := false
+			for j := len(buckets) - 1; j >= 0; j-- {
+				if used[j] {
+					running.Mul(&running, &buckets[j])
+					started = true
+				}
+				if started {
+					sum.Mul(&sum, &running)
+				}
+			}
+			windows[w].Set(&sum)
+		}
+	})
+
+	// ∏ᵥ windowᵥ^(2^(cv))
+	res := windows[nbWindows-1]
+	for w := nbWindows - 2; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			res.CyclotomicSquare(&res)
+		}
+		res.Mul(&res, &windows[w])
+	}
+	z.Set(&res)
+
+	return z, nil
+}
+
 // InverseUnitary inverse a unitary element
 func (z *E24) InverseUnitary(x *E24) *E24 {
 	return z.Conjugate(x)
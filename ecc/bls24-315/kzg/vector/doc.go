@@ -0,0 +1,26 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package vector provides a KZG vector commitment with updatable openings,
+// as used by Verkle trees and stateless clients (Tomescu et al., "Aggregatable
+// Subvector Commitments for Stateless Cryptocurrencies", SCN 2020).
+//
+// A vector v of size n (a power of 2) is committed as the KZG commitment of the
+// polynomial φ interpolating it on the n-th roots of unity: C = ∑ᵢvᵢ[Lᵢ(τ)]G₁.
+// The opening of position i is a KZG opening of φ at ωⁱ. When vⱼ changes by δ,
+// the commitment and every opening are updated in O(1) with the update keys
+// [(Lᵢ(τ)-1)/(τ-ωⁱ)]G₁ and the Lagrange basis.
+package vector
@@ -0,0 +1,297 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package vector
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidSize  = errors.New("the size of the vector must be a power of 2, at most the size of the SRS")
+	ErrInvalidIndex = errors.New("index out of range")
+)
+
+// ProvingKey used to commit to vectors, open them, and update commitments and openings
+type ProvingKey struct {
+	Lagrange  []bls24315.G1Affine // [Lᵢ(τ)]G₁
+	Updates   []bls24315.G1Affine // [(Lᵢ(τ)-1)/(τ-ωⁱ)]G₁
+	Generator fr.Element          // ω, n-th root of unity
+}
+
+// VerifyingKey used to verify openings
+type VerifyingKey struct {
+	kzg.VerifyingKey
+	Generator fr.Element // ω, n-th root of unity
+}
+
+// Setup derives the keys for vectors of the given size from a KZG SRS.
+//
+// [Lᵢ(τ)]G₁ = FFT_inv([τʲ]G₁). The quotient (Lᵢ(X)-1)/(X-ωⁱ) has coefficients
+// (n-1-k)/n·ω⁻ⁱ⁽ᵏ⁺¹⁾, hence the update keys are FFT_inv([(n-j)τʲ⁻¹]G₁), with 0 at j=0.
+func Setup(srs *kzg.SRS, size uint64) (ProvingKey, VerifyingKey, error) {
+	if size < 2 || bits.OnesCount64(size) != 1 || size > uint64(len(srs.Pk.G1)) {
+		return ProvingKey{}, VerifyingKey{}, ErrInvalidSize
+	}
+	var pk ProvingKey
+	var vk VerifyingKey
+	var err error
+	if pk.Generator, err = fr.Generator(size); err != nil {
+		return ProvingKey{}, VerifyingKey{}, err
+	}
+	vk.VerifyingKey = srs.Vk
+	vk.Generator = pk.Generator
+
+	if pk.Lagrange, err = kzg.ToLagrangeG1(srs.Pk.G1[:size]); err != nil {
+		return ProvingKey{}, VerifyingKey{}, err
+	}
+
+	shifted := make([]bls24315.G1Affine, size)
+	parallel.Execute(int(size)-1, func(start, end int) {
+		var k big.Int
+		for j := start + 1; j < end+1; j++ {
+			k.SetUint64(size - uint64(j))
+			shifted[j].ScalarMultiplication(&srs.Pk.G1[j-1], &k)
+		}
+	})
+	if pk.Updates, err = kzg.ToLagrangeG1(shifted); err != nil {
+		return ProvingKey{}, VerifyingKey{}, err
+	}
+
+	return pk, vk, nil
+}
+
+// Size returns the size of the vectors the key commits to
+func (pk *ProvingKey) Size() uint64 {
+	return uint64(len(pk.Lagrange))
+}
+
+// Commit commits to a vector of size pk.Size()
+func Commit(values []fr.Element, pk ProvingKey) (kzg.Digest, error) {
+	if uint64(len(values)) != pk.Size() {
+		return kzg.Digest{}, ErrInvalidSize
+	}
+	var res kzg.Digest
+	if _, err := res.MultiExp(pk.Lagrange, values, ecc.MultiExpConfig{}); err != nil {
+		return kzg.Digest{}, err
+	}
+	return res, nil
+}
+
+// Open computes the opening proof of values at index, that is the KZG opening
+// of the interpolating polynomial φ at ωⁱ.
+//
+// The quotient q = (φ-vᵢ)/(X-ωⁱ) is computed in Lagrange form:
+// q(ωᵏ) = (vₖ-vᵢ)/(ωᵏ-ωⁱ) for k ≠ i, and q(ωⁱ) = φ'(ωⁱ) = -∑_{k≠i}ωᵏ⁻ⁱq(ωᵏ).
+func Open(values []fr.Element, index uint64, pk ProvingKey) (kzg.OpeningProof, error) {
+	n := pk.Size()
+	if uint64(len(values)) != n {
+		return kzg.OpeningProof{}, ErrInvalidSize
+	}
+	if index >= n {
+		return kzg.OpeningProof{}, ErrInvalidIndex
+	}
+
+	// ωᵏ⁻ⁱ
+	powers := make([]fr.Element, n)
+	powers[index].SetOne()
+	for k := (index + 1) % n; k != index; k = (k + 1) % n {
+		powers[k].Mul(&powers[(k+n-1)%n], &pk.Generator)
+	}
+
+	// ωᵏ-ωⁱ = ωⁱ(ωᵏ⁻ⁱ-1)
+	omegaI := pk.power(index)
+	var one fr.Element
+	one.SetOne()
+	denominators := make([]fr.Element, n)
+	for k := range denominators {
+		if uint64(k) != index {
+			denominators[k].Sub(&powers[k], &one).Mul(&denominators[k], &omegaI)
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	quotient := make([]fr.Element, n)
+	var t fr.Element
+	for k := range quotient {
+		if uint64(k) == index {
+			continue
+		}
+		quotient[k].Sub(&values[k], &values[index]).Mul(&quotient[k], &denominators[k])
+		t.Mul(&quotient[k], &powers[k])
+		quotient[index].Sub(&quotient[index], &t)
+	}
+
+	res := kzg.OpeningProof{ClaimedValue: values[index]}
+	if _, err := res.H.MultiExp(pk.Lagrange, quotient, ecc.MultiExpConfig{}); err != nil {
+		return kzg.OpeningProof{}, err
+	}
+	return res, nil
+}
+
+// Verify verifies the opening proof of the committed vector at index
+func Verify(commitment *kzg.Digest, index uint64, proof *kzg.OpeningProof, vk VerifyingKey) error {
+	var point fr.Element
+	point.Exp(vk.Generator, new(big.Int).SetUint64(index))
+	return kzg.Verify(commitment, proof, point, vk.VerifyingKey)
+}
+
+// UpdateCommitment updates the commitment after values[index] is increased by delta:
+// C' = C + δ[Lᵢ(τ)]G₁
+func UpdateCommitment(commitment *kzg.Digest, index uint64, delta fr.Element, pk ProvingKey) error {
+	if index >= pk.Size() {
+		return ErrInvalidIndex
+	}
+	var t bls24315.G1Affine
+	t.ScalarMultiplication(&pk.Lagrange[index], delta.BigInt(new(big.Int)))
+	commitment.Add(commitment, &t)
+	return nil
+}
+
+// UpdateProof updates the opening proof at index after values[changed] is increased by delta.
+//
+// If changed = i, H' = H + δ[(Lᵢ(τ)-1)/(τ-ωⁱ)]G₁.
+// Otherwise Lⱼ(X)/(X-ωⁱ) = (ωʲ⁻ⁱLᵢ(X) - Lⱼ(X))/(ωⁱ-ωʲ), so that
+// H' = H + δ/(ωⁱ-ωʲ)·(ωʲ⁻ⁱ[Lᵢ(τ)]G₁ - [Lⱼ(τ)]G₁).
+func UpdateProof(proof *kzg.OpeningProof, index, changed uint64, delta fr.Element, pk ProvingKey) error {
+	n := pk.Size()
+	if index >= n || changed >= n {
+		return ErrInvalidIndex
+	}
+	var b big.Int
+	if index == changed {
+		var t bls24315.G1Affine
+		t.ScalarMultiplication(&pk.Updates[index], delta.BigInt(&b))
+		proof.H.Add(&proof.H, &t)
+		proof.ClaimedValue.Add(&proof.ClaimedValue, &delta)
+		return nil
+	}
+
+	// c = δ/(ωⁱ-ωʲ)
+	omegaI, omegaJ := pk.power(index), pk.power(changed)
+	var c, cI fr.Element
+	c.Sub(&omegaI, &omegaJ).Inverse(&c).Mul(&c, &delta)
+	cI = pk.power((changed + n - index) % n)
+	cI.Mul(&cI, &c)
+
+	var h, t bls24315.G1Jac
+	h.FromAffine(&proof.H)
+	t.ScalarMultiplicationAffine(&pk.Lagrange[index], cI.BigInt(&b))
+	h.AddAssign(&t)
+	t.ScalarMultiplicationAffine(&pk.Lagrange[changed], c.BigInt(&b))
+	h.SubAssign(&t)
+	proof.H.FromJacobian(&h)
+	return nil
+}
+
+// power returns ωⁱ
+func (pk *ProvingKey) power(i uint64) fr.Element {
+	var res fr.Element
+	res.Exp(pk.Generator, new(big.Int).SetUint64(i))
+	return res
+}
+
+// Vector is a committed vector, which caches the openings it computed and keeps
+// them up to date when its entries change.
+type Vector struct {
+	pk         ProvingKey
+	values     []fr.Element
+	commitment kzg.Digest
+	proofs     map[uint64]*kzg.OpeningProof
+	lock       sync.RWMutex
+}
+
+// NewVector commits to values, of size pk.Size()
+func NewVector(values []fr.Element, pk ProvingKey) (*Vector, error) {
+	commitment, err := Commit(values, pk)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector{
+		pk:         pk,
+		values:     append([]fr.Element{}, values...),
+		commitment: commitment,
+		proofs:     make(map[uint64]*kzg.OpeningProof),
+	}, nil
+}
+
+// Commitment returns the commitment to the current values
+func (v *Vector) Commitment() kzg.Digest {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.commitment
+}
+
+// Get returns the value at index
+func (v *Vector) Get(index uint64) (fr.Element, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	if index >= uint64(len(v.values)) {
+		return fr.Element{}, ErrInvalidIndex
+	}
+	return v.values[index], nil
+}
+
+// Open returns the opening proof at index, and caches it so that it is kept up to date by Set
+func (v *Vector) Open(index uint64) (kzg.OpeningProof, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if proof, ok := v.proofs[index]; ok {
+		return *proof, nil
+	}
+	proof, err := Open(v.values, index, v.pk)
+	if err != nil {
+		return kzg.OpeningProof{}, err
+	}
+	v.proofs[index] = &proof
+	return proof, nil
+}
+
+// Set sets the value at index, and updates the commitment and the cached openings in O(1) each
+func (v *Vector) Set(index uint64, value fr.Element) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if index >= uint64(len(v.values)) {
+		return ErrInvalidIndex
+	}
+	var delta fr.Element
+	delta.Sub(&value, &v.values[index])
+	if err := UpdateCommitment(&v.commitment, index, delta, v.pk); err != nil {
+		return err
+	}
+	v.values[index] = value
+
+	proofs := make([]uint64, 0, len(v.proofs))
+	for i := range v.proofs {
+		proofs = append(proofs, i)
+	}
+	parallel.Execute(len(proofs), func(start, end int) {
+		for _, i := range proofs[start:end] {
+			// indices are in range, UpdateProof can't fail
+			_ = UpdateProof(v.proofs[i], i, index, delta, v.pk)
+		}
+	})
+	return nil
+}
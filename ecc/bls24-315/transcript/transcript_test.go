@@ -0,0 +1,154 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package transcript
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	"github.com/stretchr/testify/assert"
+)
+
+// run appends values to t and returns the challenges computed along the way
+func run(t *Transcript, labels ...string) ([]fr.Element, error) {
+	_, _, g1, g2 := bls24315.Generators()
+	var s fr.Element
+	s.SetUint64(42)
+
+	if err := t.AppendScalar(labels[0], &s); err != nil {
+		return nil, err
+	}
+	if err := t.AppendG1(labels[1], &g1); err != nil {
+		return nil, err
+	}
+	alpha, err := t.ChallengeScalar("alpha")
+	if err != nil {
+		return nil, err
+	}
+	if err = t.AppendG2("g2", &g2); err != nil {
+		return nil, err
+	}
+	if err = t.AppendBytes("bytes", []byte("message")); err != nil {
+		return nil, err
+	}
+	betas, err := t.ChallengeScalars("beta", 3)
+	if err != nil {
+		return nil, err
+	}
+	return append([]fr.Element{alpha}, betas...), nil
+}
+
+func TestTranscript(t *testing.T) {
+	for name, newTranscript := range map[string]func(string) *Transcript{
+		"hash": func(domain string) *Transcript { return New(domain) },
+		"sponge": func(domain string) *Transcript {
+			tr, err := NewSponge(domain)
+			if err != nil {
+				panic(err)
+			}
+			return tr
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			c1, err := run(newTranscript("test"), "s", "g1")
+			assert.NoError(err)
+			c2, err := run(newTranscript("test"), "s", "g1")
+			assert.NoError(err)
+			assert.Equal(c1, c2, "transcripts must be deterministic")
+			for i := 1; i < len(c1); i++ {
+				assert.NotEqual(c1[i-1], c1[i], "challenges must differ")
+			}
+
+			c2, err = run(newTranscript("other"), "s", "g1")
+			assert.NoError(err)
+			assert.NotEqual(c1[0], c2[0], "domain must separate transcripts")
+
+			c2, err = run(newTranscript("test"), "g1", "s")
+			assert.NoError(err)
+			assert.NotEqual(c1[0], c2[0], "labels must separate transcripts")
+
+			b1, err := newTranscript("test").ChallengeBytes("bytes", 100)
+			assert.NoError(err)
+			b2, err := newTranscript("test").ChallengeBytes("bytes", 40)
+			assert.NoError(err)
+			assert.Equal(100, len(b1))
+			assert.NotEqual(b1[:40], b2, "the number of squeezed bytes must be bound")
+		})
+	}
+}
+
+// TestSpongeMiMC checks that the sponge mode computes what a verifier using MiMC directly, e.g. in a circuit, does.
+func TestSpongeMiMC(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := NewSponge("test")
+	assert.NoError(err)
+	var x fr.Element
+	x.SetUint64(3)
+	assert.NoError(tr.AppendScalar("x", &x))
+	c, err := tr.ChallengeScalars("c", 2)
+	assert.NoError(err)
+
+	// frame returns the domain separation element: op ‖ len(label) ‖ label
+	frame := func(op byte, label string) []byte {
+		b := make([]byte, fr.Bytes)
+		offset := fr.Bytes - len(label)
+		copy(b[offset:], label)
+		binary.BigEndian.PutUint32(b[offset-4:], uint32(len(label)))
+		b[offset-5] = op
+		return b
+	}
+	h := mimc.NewMiMC()
+	h.Write(frame(0, "test"))
+	xBytes := x.Bytes()
+	h.Write(frame(3, "x"))
+	h.Write(xBytes[:])
+	h.Write(frame(4, "c"))
+	var expected fr.Element
+	expected.SetBytes(h.Sum(nil))
+	assert.Equal(expected, c[0])
+	h.Write(h.Sum(nil))
+	expected.SetBytes(h.Sum(nil))
+	assert.Equal(expected, c[1])
+
+	_, err = tr.ChallengeScalar("a label that does not fit in a field element")
+	assert.Error(err)
+}
+
+func BenchmarkTranscript(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	b.Run("hash", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := New("bench")
+			t.AppendScalar("s", &s)
+			t.ChallengeScalar("c")
+		}
+	})
+	b.Run("sponge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t, _ := NewSponge("bench")
+			t.AppendScalar("s", &s)
+			t.ChallengeScalar("c")
+		}
+	})
+}
@@ -19,13 +19,13 @@ package fr
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"io"
 	"math/big"
 	"math/bits"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/bits-and-blooms/bitset"
 	"github.com/consensys/gnark-crypto/field/hash"
@@ -1032,26 +1032,28 @@ func (z *Element) SetString(number string) (*Element, error) {
 	return z, nil
 }
 
-// MarshalJSON returns json encoding of z (z.Text(10))
+// MarshalJSON returns the JSON encoding of z, the JSON string holding its canonical
+// hex encoding (see MarshalText):
+//
+//	"0x…"
+//
 // If z == nil, returns null
 func (z *Element) MarshalJSON() ([]byte, error) {
 	if z == nil {
 		return []byte("null"), nil
 	}
-	const maxSafeBound = 15 // we encode it as number if it's small
-	s := z.Text(10)
-	if len(s) <= maxSafeBound {
-		return []byte(s), nil
-	}
-	var sbb strings.Builder
-	sbb.WriteByte('"')
-	sbb.WriteString(s)
-	sbb.WriteByte('"')
-	return []byte(sbb.String()), nil
+	text, _ := z.MarshalText()
+	res := make([]byte, 0, len(text)+2)
+	res = append(res, '"')
+	res = append(res, text...)
+	res = append(res, '"')
+	return res, nil
 }
 
-// UnmarshalJSON accepts numbers and strings as input
-// See Element.SetString for valid prefixes (0x, 0b, ...)
+// UnmarshalJSON accepts numbers and strings as input.
+// Strings prefixed with "0x" are canonical hex encodings (see UnmarshalText): values greater
+// than or equal to the modulus are rejected. Other values are parsed as in
+// Element.SetString (decimal, or 0b, 0o prefixes) and reduced modulo q.
 func (z *Element) UnmarshalJSON(data []byte) error {
 	s := string(data)
 	if len(s) > Bits*3 {
@@ -1066,6 +1068,10 @@ func (z *Element) UnmarshalJSON(data []byte) error {
 		s = s[:len(s)-1]
 	}
 
+	if hasHexPrefix(s) {
+		return z.UnmarshalText([]byte(s))
+	}
+
 	// get temporary big int from the pool
 	vv := pool.BigInt.Get()
 
@@ -1080,6 +1086,47 @@ func (z *Element) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalText returns the canonical hex encoding of z: "0x" followed by the
+// 2*Bytes hex digits of the regular (non montgomery) big-endian value of z.
+func (z *Element) MarshalText() ([]byte, error) {
+	b := z.Bytes()
+	res := make([]byte, 2+2*Bytes)
+	res[0], res[1] = '0', 'x'
+	hex.Encode(res[2:], b[:])
+	return res, nil
+}
+
+// UnmarshalText sets z from a hex encoding, as returned by MarshalText.
+// The "0x" prefix is required, leading zeroes are optional, and the value must be
+// smaller than the modulus.
+func (z *Element) UnmarshalText(text []byte) error {
+	if !hasHexPrefix(string(text)) {
+		return errors.New("invalid hex encoding of Element: missing 0x prefix")
+	}
+	text = text[2:]
+	if len(text) == 0 || len(text) > 2*Bytes {
+		return errors.New("invalid hex encoding of Element")
+	}
+
+	// left pad with zeroes
+	var padded [2 * Bytes]byte
+	for i := 0; i < len(padded)-len(text); i++ {
+		padded[i] = '0'
+	}
+	copy(padded[len(padded)-len(text):], text)
+
+	var b [Bytes]byte
+	if _, err := hex.Decode(b[:], padded[:]); err != nil {
+		return err
+	}
+	return z.SetBytesCanonical(b[:])
+}
+
+// hasHexPrefix returns true if s starts with "0x" or "0X"
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
 // A ByteOrder specifies how to convert byte slices into a Element
 type ByteOrder interface {
 	Element(*[Bytes]byte) (Element, error)
@@ -0,0 +1,154 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+func TestSmoothCardinality(t *testing.T) {
+	for _, m := range []uint64{3 << 4, 3<<4 - 7, 3 << 10, 5000} {
+		domain := NewDomain(m, WithSmoothCardinality(), WithoutPrecompute())
+		n := domain.Cardinality
+		if n < m {
+			t.Fatalf("cardinality %d < %d", n, m)
+		}
+		if m%3 == 0 && isPowerOfTwo(m/3) && n != m {
+			t.Fatalf("3·2ᵏ = %d should not be rounded up (got %d)", m, n)
+		}
+		if _, smooth := radixFactors(n); !smooth {
+			t.Fatalf("cardinality %d is not smooth", n)
+		}
+		for i := m; i < n; i++ {
+			if _, err := checkExactCardinality(i); err == nil {
+				if _, smooth := radixFactors(i); smooth {
+					t.Fatalf("%d is a smaller smooth cardinality than %d", i, n)
+				}
+			}
+		}
+	}
+}
+
+// fftLargeFactor is a prime factor of r-1 larger than maxRadix
+const fftLargeFactor = 137
+
+func TestFFTMixed(t *testing.T) {
+	var smooth uint64 = 3 << 6
+	if _, s := radixFactors(smooth * 5); s {
+		smooth *= 5
+	}
+
+	for _, size := range []uint64{smooth, fftLargeFactor, 2 * fftLargeFactor} {
+		for _, opts := range [][]DomainOption{
+			{WithExactCardinality()},
+			{WithExactCardinality(), WithoutPrecompute()},
+		} {
+			if _, err := checkExactCardinality(size); err != nil {
+				continue
+			}
+			domain := NewDomain(size, opts...)
+			if domain.Cardinality != size {
+				t.Fatalf("expected cardinality %d, got %d", size, domain.Cardinality)
+			}
+			checkFFTMixed(t, domain)
+		}
+	}
+}
+
+func checkFFTMixed(t *testing.T, domain *Domain) {
+	n := int(domain.Cardinality)
+
+	// the generator has order exactly n
+	var one, x fr.Element
+	one.SetOne()
+	factors, _ := radixFactors(domain.Cardinality)
+	for _, p := range append(factors, fftLargeFactor) {
+		if domain.Cardinality%p != 0 {
+			continue
+		}
+		x.Exp(domain.Generator, big.NewInt(int64(domain.Cardinality/p)))
+		if x.Equal(&one) {
+			t.Fatalf("generator has order smaller than %d", n)
+		}
+	}
+	x.Exp(domain.Generator, big.NewInt(int64(n)))
+	if !x.Equal(&one) {
+		t.Fatal("generator is not a root of unity of order n")
+	}
+
+	pol := make([]fr.Element, n)
+	backupPol := make([]fr.Element, n)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	copy(backupPol, pol)
+
+	indices := []int{0, 1, n / 3, n - 1}
+
+	domain.FFT(pol, DIF)
+	for _, i := range indices {
+		var sample fr.Element
+		sample.Exp(domain.Generator, big.NewInt(int64(i)))
+		eval := evaluatePolynomial(backupPol, sample)
+		if !eval.Equal(&pol[i]) {
+			t.Fatalf("n=%d: FFT inconsistent with evaluation at ω^%d", n, i)
+		}
+	}
+	domain.FFTInverse(pol, DIT)
+	for i := range pol {
+		if !pol[i].Equal(&backupPol[i]) {
+			t.Fatalf("n=%d: FFTInverse(FFT) != id", n)
+		}
+	}
+
+	domain.FFT(pol, DIT, OnCoset())
+	for _, i := range indices {
+		var sample fr.Element
+		sample.Exp(domain.Generator, big.NewInt(int64(i))).
+			Mul(&sample, &domain.FrMultiplicativeGen)
+		eval := evaluatePolynomial(backupPol, sample)
+		if !eval.Equal(&pol[i]) {
+			t.Fatalf("n=%d: FFT on coset inconsistent with evaluation at u·ω^%d", n, i)
+		}
+	}
+	domain.FFTInverse(pol, DIF, OnCoset())
+	for i := range pol {
+		if !pol[i].Equal(&backupPol[i]) {
+			t.Fatalf("n=%d: FFTInverse(FFT) != id on coset", n)
+		}
+	}
+}
+
+func BenchmarkFFTMixed(b *testing.B) {
+	for _, size := range []uint64{3 << 14, 3 << 18} {
+		pol := make([]fr.Element, size)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		domain := NewDomain(size, WithSmoothCardinality())
+		b.Run("3·2ᵏ/"+strconv.Itoa(int(size)), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol, DIF)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+func TestFFTReaderAt(t *testing.T) {
+	for _, size := range []uint64{1 << 6, 1 << 9} {
+		domain := NewDomain(size)
+
+		v := make(fr.Vector, size)
+		for i := range v {
+			v[i].SetRandom()
+		}
+		var src bytes.Buffer
+		if _, err := v.WriteTo(&src); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, opts := range [][]Option{
+			{WithChunkSize(1)},
+			{WithChunkSize(37)},
+			{WithChunkSize(int(size)), OnCoset()},
+			{OnCoset()},
+		} {
+			expected := make([]fr.Element, size)
+			copy(expected, v)
+			domain.FFT(expected, DIF, opts...)
+			BitReverse(expected)
+
+			f, err := os.Create(filepath.Join(t.TempDir(), "fft"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			if err := domain.FFTReaderAt(f, bytes.NewReader(src.Bytes()), opts...); err != nil {
+				t.Fatal(err)
+			}
+			var res fr.Vector
+			if _, err := f.Seek(0, 0); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := res.ReadFrom(f); err != nil {
+				t.Fatal(err)
+			}
+			if !vectorEqual(res, expected) {
+				t.Fatalf("size %d: out-of-core FFT doesn't match FFT", size)
+			}
+
+			// inverse, from the output file
+			g, err := os.Create(filepath.Join(t.TempDir(), "fftinv"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer g.Close()
+			if err := domain.FFTInverseReaderAt(g, f, opts...); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.Seek(0, 0); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := res.ReadFrom(g); err != nil {
+				t.Fatal(err)
+			}
+			if !vectorEqual(res, v) {
+				t.Fatalf("size %d: out-of-core FFTInverse(FFT) != id", size)
+			}
+		}
+	}
+}
+
+func vectorEqual(a, b []fr.Element) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+)
+
+// multiplication thresholds, see BenchmarkPolynomialMul
+const (
+	// karatsubaThreshold is the length of the smallest operand above which products use Karatsuba
+	karatsubaThreshold = 24
+	// fftMulThreshold is the length of the smallest operand above which products are computed with FFTs
+	fftMulThreshold = 48
+)
+
+// ErrNotDivisible is returned when an exact division has a non zero remainder
+var ErrNotDivisible = errors.New("polynomial is not divisible")
+
+// Mul sets p to p1·p2 and returns p. The result has len(p1)+len(p2)-1 coefficients.
+//
+// The algorithm depends on the length of the smallest operand: schoolbook for small polynomials,
+// then Karatsuba, then FFT (NTT) multiplication.
+// This function allocates a new slice; p may alias p1 or p2.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	*p = mul(p1, p2)
+	return p
+}
+
+// Div sets p to the quotient of the euclidean division of p1 by p2 and returns p. See DivRem.
+// It panics if p2 is zero.
+func (p *Polynomial) Div(p1, p2 Polynomial) *Polynomial {
+	*p, _ = DivRem(p1, p2)
+	return p
+}
+
+// DivideByVanishing sets p to p1/(Xⁿ - 1), the vanishing polynomial of the subgroup of order n, and returns p.
+// It runs in O(len(p1)). If the remainder is not zero, p is set to the quotient of the euclidean division
+// and ErrNotDivisible is returned.
+func (p *Polynomial) DivideByVanishing(p1 Polynomial, n uint64) (*Polynomial, error) {
+	if n == 0 {
+		panic("division by zero polynomial")
+	}
+	divisible := true
+	if uint64(len(p1)) <= n {
+		for i := range p1 {
+			if !p1[i].IsZero() {
+				divisible = false
+			}
+		}
+		*p = Polynomial{}
+	} else {
+		// p1 = q·(Xⁿ - 1) + r, hence q[i] = p1[i+n] + q[i+n] and r[i] = p1[i] + q[i]
+		q := make(Polynomial, uint64(len(p1))-n)
+		for i := len(q) - 1; i >= 0; i-- {
+			q[i] = p1[uint64(i)+n]
+			if j := uint64(i) + n; j < uint64(len(q)) {
+				q[i].Add(&q[i], &q[j])
+			}
+		}
+		var r fr.Element
+		for i := uint64(0); i < n && i < uint64(len(q)); i++ {
+			if r.Add(&p1[i], &q[i]); !r.IsZero() {
+				divisible = false
+				break
+			}
+		}
+		for i := uint64(len(q)); divisible && i < n; i++ {
+			divisible = p1[i].IsZero()
+		}
+		*p = q
+	}
+	if !divisible {
+		return p, ErrNotDivisible
+	}
+	return p, nil
+}
+
+// Derivative sets p to the formal derivative of p1 and returns p.
+// This function allocates a new slice; p may alias p1.
+func (p *Polynomial) Derivative(p1 Polynomial) *Polynomial {
+	if len(p1) <= 1 {
+		*p = Polynomial{}
+		return p
+	}
+	res := make(Polynomial, len(p1)-1)
+	var c fr.Element
+	for i := 1; i < len(p1); i++ {
+		c.SetUint64(uint64(i))
+		res[i-1].Mul(&p1[i], &c)
+	}
+	*p = res
+	return p
+}
+
+// Compose sets p to p1(p2(X)) and returns p.
+//
+// It splits p1 = p1ₗ + Xʰ·p1ₕ with h a power of 2, and recursively computes p1ₗ(p2) + p2ʰ·p1ₕ(p2),
+// the powers p2^(2ᵏ) being computed once.
+// This function allocates a new slice; p may alias p1 or p2.
+func (p *Polynomial) Compose(p1, p2 Polynomial) *Polynomial {
+	p1, p2 = p1.trimmed(), p2.trimmed()
+	switch {
+	case len(p1) == 0:
+		*p = Polynomial{}
+		return p
+	case len(p2) <= 1:
+		var x fr.Element
+		if len(p2) == 1 {
+			x = p2[0]
+		}
+		*p = Polynomial{p1.Eval(&x)}
+		return p
+	}
+
+	// powers[k] = p2^(2ᵏ)
+	powers := []Polynomial{p2}
+	for 1<<len(powers) < len(p1) {
+		last := powers[len(powers)-1]
+		powers = append(powers, mul(last, last))
+	}
+	*p = compose(p1, powers)
+	return p
+}
+
+// compose returns f(g), where powers[k] = g^(2ᵏ) for 2ᵏ < len(f)
+func compose(f Polynomial, powers []Polynomial) Polynomial {
+	if len(f) == 1 {
+		return Polynomial{f[0]}
+	}
+	k := bits.Len(uint(len(f)-1)) - 1
+	h := 1 << k
+	lo := compose(f[:h], powers)
+	hi := mul(compose(f[h:], powers), powers[k])
+	hi.Add(hi, lo)
+	return hi
+}
+
+// mul returns a·b in a new polynomial of len(a)+len(b)-1 coefficients
+func mul(a, b Polynomial) Polynomial {
+	if len(a) == 0 || len(b) == 0 {
+		return Polynomial{}
+	}
+	switch m := min(len(a), len(b)); {
+	case m < karatsubaThreshold:
+		return mulSchoolbook(a, b)
+	case m < fftMulThreshold:
+		return mulKaratsuba(a, b)
+	default:
+		return mulFFT(a, b)
+	}
+}
+
+func mulSchoolbook(a, b Polynomial) Polynomial {
+	res := make(Polynomial, len(a)+len(b)-1)
+	var t fr.Element
+	for i := range a {
+		for j := range b {
+			t.Mul(&a[i], &b[j])
+			res[i+j].Add(&res[i+j], &t)
+		}
+	}
+	return res
+}
+
+// mulKaratsuba cuts the largest operand in chunks of the size of the smallest one,
+// and multiplies them with karatsuba
+func mulKaratsuba(a, b Polynomial) Polynomial {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	m := len(b)
+	res := make(Polynomial, len(a)+m-1)
+	chunk := make(Polynomial, m)
+	for i := 0; i < len(a); i += m {
+		n := copy(chunk, a[i:])
+		for j := n; j < m; j++ {
+			chunk[j].SetZero()
+		}
+		prod := karatsuba(chunk, b)
+		for j := 0; j < len(prod) && i+j < len(res); j++ {
+			res[i+j].Add(&res[i+j], &prod[j])
+		}
+	}
+	return res
+}
+
+// karatsuba returns a·b for len(a) = len(b)
+func karatsuba(a, b Polynomial) Polynomial {
+	n := len(a)
+	if n < karatsubaThreshold {
+		return mulSchoolbook(a, b)
+	}
+	h := n / 2
+
+	// (a₀ + Xʰa₁)(b₀ + Xʰb₁) = z₀ + Xʰ((a₀+a₁)(b₀+b₁) - z₀ - z₂) + X²ʰz₂
+	z0 := karatsuba(a[:h], b[:h])
+	z2 := karatsuba(a[h:], b[h:])
+	sa := make(Polynomial, n-h)
+	sb := make(Polynomial, n-h)
+	copy(sa, a[h:])
+	copy(sb, b[h:])
+	for i := 0; i < h; i++ {
+		sa[i].Add(&sa[i], &a[i])
+		sb[i].Add(&sb[i], &b[i])
+	}
+	z1 := karatsuba(sa, sb)
+	for i := range z0 {
+		z1[i].Sub(&z1[i], &z0[i])
+	}
+	for i := range z2 {
+		z1[i].Sub(&z1[i], &z2[i])
+	}
+
+	res := make(Polynomial, 2*n-1)
+	copy(res, z0)
+	copy(res[2*h:], z2)
+	for i := range z1 {
+		res[h+i].Add(&res[h+i], &z1[i])
+	}
+	return res
+}
+
+func mulFFT(a, b Polynomial) Polynomial {
+	n := len(a) + len(b) - 1
+	domain := getDomain(uint64(n))
+	size := int(domain.Cardinality)
+
+	_a := make(Polynomial, size)
+	_b := make(Polynomial, size)
+	copy(_a, a)
+	copy(_b, b)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		domain.FFT(_a, fft.DIF)
+		wg.Done()
+	}()
+	domain.FFT(_b, fft.DIF)
+	wg.Wait()
+
+	for i := range _a {
+		_a[i].Mul(&_a[i], &_b[i])
+	}
+	domain.FFTInverse(_a, fft.DIT)
+	return _a[:n]
+}
+
+// maxCachedDomainLog is the log₂ of the cardinality of the largest cached fft domain. Larger
+// domains are rebuilt for each product: their precomputation is cheap next to the FFTs, and
+// caching them would retain their twiddles for the lifetime of the process.
+const maxCachedDomainLog = 16
+
+// domains caches the power of 2 fft domains used for multiplications; domains[k] has cardinality 2ᵏ
+var domains [maxCachedDomainLog + 1]struct {
+	once   sync.Once
+	domain *fft.Domain
+}
+
+// getDomain returns a domain of cardinality the smallest power of 2 ≥ n
+func getDomain(n uint64) *fft.Domain {
+	log := bits.Len64(n - 1)
+	if log > maxCachedDomainLog {
+		return fft.NewDomain(uint64(1) << log)
+	}
+	d := &domains[log]
+	d.once.Do(func() {
+		d.domain = fft.NewDomain(uint64(1) << log)
+	})
+	return d.domain
+}
+
+// trimmed returns p without its leading zero coefficients; the zero polynomial is empty
+func (p Polynomial) trimmed() Polynomial {
+	n := len(p)
+	for n > 0 && p[n-1].IsZero() {
+		n--
+	}
+	return p[:n]
+}
+
+// reversed returns the coefficients of p in reverse order, in a new polynomial
+func reversed(p Polynomial) Polynomial {
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[len(p)-1-i] = p[i]
+	}
+	return res
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
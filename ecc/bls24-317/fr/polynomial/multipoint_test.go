@@ -0,0 +1,160 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPolynomial(size int) Polynomial {
+	p := make(Polynomial, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func randomPoints(n int) []fr.Element {
+	points := make([]fr.Element, n)
+	for i := range points {
+		points[i].SetRandom()
+	}
+	return points
+}
+
+func TestVanishingPolynomial(t *testing.T) {
+	assert := assert.New(t)
+
+	points := randomPoints(37)
+	z := VanishingPolynomial(points)
+	assert.Equal(len(points)+1, len(z))
+	assert.True(z[len(z)-1].IsOne(), "vanishing polynomial must be monic")
+	for i := range points {
+		v := z.Eval(&points[i])
+		assert.True(v.IsZero(), "vanishing polynomial must vanish on the points")
+	}
+	x := randomPoints(1)[0]
+	var expected, t_ fr.Element
+	expected.SetOne()
+	for i := range points {
+		t_.Sub(&x, &points[i])
+		expected.Mul(&expected, &t_)
+	}
+	v := z.Eval(&x)
+	assert.True(v.Equal(&expected))
+}
+
+func TestEvalMultipoint(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, size := range [][2]int{{10, 5}, {100, 70}, {100, 300}, {600, 513}} {
+		p := randomPolynomial(size[0])
+		points := randomPoints(size[1])
+		evals := p.EvalMultipoint(points)
+		assert.Equal(len(points), len(evals))
+		for i := range points {
+			expected := p.Eval(&points[i])
+			assert.True(expected.Equal(&evals[i]), "deg=%d, n=%d: mismatch at %d", size[0]-1, size[1], i)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, n := range []int{1, 17, 150, 1025} {
+		points := randomPoints(n)
+		values := randomPoints(n)
+		p, err := Interpolate(points, values)
+		assert.NoError(err)
+		assert.Equal(n, len(p))
+		for i := range points {
+			v := p.Eval(&points[i])
+			assert.True(v.Equal(&values[i]), "n=%d: mismatch at %d", n, i)
+		}
+	}
+
+	points := randomPoints(40)
+	points[31] = points[7]
+	_, err := Interpolate(points, randomPoints(40))
+	assert.ErrorIs(err, ErrDuplicatePoints)
+}
+
+func TestDivRem(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{10, 3}, {3, 10}, {200, 1}, {300, 100}, {1000, 200}, {513, 400}} {
+		a := randomPolynomial(sizes[0])
+		b := randomPolynomial(sizes[1])
+		q, r := DivRem(a, b)
+		assert.Equal(len(b)-1, len(r))
+
+		// a = q·b + r
+		res := mulSchoolbook(q, b)
+		res.Add(res, r)
+		if len(q) == 0 {
+			res = r
+		}
+		res = res.trimmed()
+		assert.True(res.Equal(a.trimmed()), "a=%d, b=%d: a != q·b + r", sizes[0], sizes[1])
+	}
+}
+
+func TestGCD(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][3]int{{4, 10, 7}, {80, 150, 100}} {
+		g := randomPolynomial(sizes[0])
+		var inv fr.Element
+		inv.Inverse(&g[len(g)-1])
+		g.ScaleInPlace(&inv)
+
+		// u and v are coprime with overwhelming probability
+		a := mul(g, randomPolynomial(sizes[1]))
+		b := mul(g, randomPolynomial(sizes[2]))
+		d := GCD(a, b)
+		assert.True(d.Equal(g), "gcd mismatch")
+	}
+
+	one := GCD(randomPolynomial(20), randomPolynomial(13))
+	assert.Equal(1, len(one))
+	assert.True(one[0].IsOne())
+	assert.Equal(0, len(GCD(Polynomial{}, Polynomial{{}})))
+}
+
+func BenchmarkEvalMultipoint(b *testing.B) {
+	const n = 1 << 14
+	p := randomPolynomial(n)
+	points := randomPoints(n)
+	tree := NewSubproductTree(points)
+
+	b.Run("horner", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range points {
+				p.Eval(&points[j])
+			}
+		}
+	})
+	b.Run("subproduct tree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.Eval(p)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hiding
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
+)
+
+// Test SRS re-used across tests of the hiding KZG scheme
+var testSrs *SRS
+
+func init() {
+	var err error
+	testSrs, err = NewSRS(64, big.NewInt(42))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomPolynomial(size int) []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func TestCommitHiding(t *testing.T) {
+	assert := require.New(t)
+
+	p := randomPolynomial(60)
+	digest, blinding, err := Commit(p, 2, testSrs.Pk)
+	assert.NoError(err)
+	assert.Len(blinding, 3)
+
+	// the commitment is blinded: it differs from the binding one, and from a second commitment
+	binding, err := kzg.Commit(p, testSrs.Pk.ProvingKey)
+	assert.NoError(err)
+	assert.False(binding.Equal(&digest))
+	other, _, err := Commit(p, 2, testSrs.Pk)
+	assert.NoError(err)
+	assert.False(other.Equal(&digest))
+
+	// it is binding for the pair (p, r̂)
+	expected, err := CommitWithBlinding(p, blinding, testSrs.Pk)
+	assert.NoError(err)
+	assert.True(expected.Equal(&digest))
+
+	_, _, err = Commit(p, 64, testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidBlindingSize)
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	p := randomPolynomial(60)
+	for _, hidingBound := range []int{0, 1, 5} {
+		digest, blinding, err := Commit(p, hidingBound, testSrs.Pk)
+		assert.NoError(err)
+
+		var point fr.Element
+		point.SetRandom()
+		proof, err := Open(p, blinding, point, testSrs.Pk)
+		assert.NoError(err)
+		expected := eval(p, point)
+		assert.True(expected.Equal(&proof.ClaimedValue))
+		assert.NoError(Verify(&digest, &proof, point, testSrs.Vk))
+
+		// wrong values
+		wrong := proof
+		wrong.ClaimedValue.Double(&wrong.ClaimedValue)
+		assert.ErrorIs(Verify(&digest, &wrong, point, testSrs.Vk), kzg.ErrVerifyOpeningProof)
+		wrong = proof
+		wrong.BlindingValue.Double(&wrong.BlindingValue)
+		assert.ErrorIs(Verify(&digest, &wrong, point, testSrs.Vk), kzg.ErrVerifyOpeningProof)
+	}
+}
+
+func TestBatchVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	const nbPolynomials = 5
+	polynomials := make([][]fr.Element, nbPolynomials)
+	blindings := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(20 + i)
+		var err error
+		digests[i], blindings[i], err = Commit(polynomials[i], i, testSrs.Pk)
+		assert.NoError(err)
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	hf := sha256.New()
+	proof, err := BatchOpenSinglePoint(polynomials, blindings, digests, point, hf, testSrs.Pk, []byte("data"))
+	assert.NoError(err)
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, hf, testSrs.Vk, []byte("data")))
+
+	// the folding challenge depends on the transcript
+	assert.Error(BatchVerifySinglePoint(digests, &proof, point, hf, testSrs.Vk, []byte("other data")))
+
+	proof.BlindingValues[1].Double(&proof.BlindingValues[1])
+	assert.Error(BatchVerifySinglePoint(digests, &proof, point, hf, testSrs.Vk, []byte("data")))
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+	assert := require.New(t)
+
+	const nbPolynomials = 6
+	digests := make([]Digest, nbPolynomials)
+	proofs := make([]OpeningProof, nbPolynomials)
+	points := make([]fr.Element, nbPolynomials)
+	for i := range digests {
+		p := randomPolynomial(30 + i)
+		var blinding []fr.Element
+		var err error
+		digests[i], blinding, err = Commit(p, 1, testSrs.Pk)
+		assert.NoError(err)
+		points[i].SetRandom()
+		proofs[i], err = Open(p, blinding, points[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+	assert.NoError(BatchVerifyMultiPoints(digests, proofs, points, testSrs.Vk))
+
+	proofs[2].BlindingValue.SetOne()
+	assert.ErrorIs(BatchVerifyMultiPoints(digests, proofs, points, testSrs.Vk), kzg.ErrVerifyOpeningProof)
+}
+
+func TestNewSRSBenchmark(t *testing.T) {
+	assert := require.New(t)
+
+	srs, err := NewSRS(16, big.NewInt(-1))
+	assert.NoError(err)
+	p := randomPolynomial(16)
+	digest, blinding, err := Commit(p, 3, srs.Pk)
+	assert.NoError(err)
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(p, blinding, point, srs.Pk)
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, srs.Vk))
+}
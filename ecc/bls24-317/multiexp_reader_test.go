@@ -0,0 +1,123 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+func TestMultiExpReaderAtG1(t *testing.T) {
+	const nbSamples = 73
+
+	points := make([]G1Affine, nbSamples)
+	scalars := make(fr.Vector, nbSamples)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := range points {
+		points[i].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+		scalars[i].SetRandom()
+	}
+	points[nbSamples/2].setInfinity()
+	scalars[nbSamples/3].SetZero()
+
+	var pointsBuf, scalarsBuf bytes.Buffer
+	if err := NewEncoder(&pointsBuf, RawEncoding()).Encode(points); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scalars.WriteTo(&scalarsBuf); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected G1Jac
+	if _, err := expected.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkSize := range []int{1, 7, nbSamples, 0} {
+		var res G1Jac
+		if _, err := res.MultiExpReaderAt(bytes.NewReader(pointsBuf.Bytes()), bytes.NewReader(scalarsBuf.Bytes()), chunkSize, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatalf("chunk size %d: out-of-core MultiExp doesn't match MultiExp", chunkSize)
+		}
+	}
+
+	// compressed points are rejected
+	var compressed bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(points); err != nil {
+		t.Fatal(err)
+	}
+	var res G1Jac
+	if _, err := res.MultiExpReaderAt(bytes.NewReader(compressed.Bytes()), bytes.NewReader(scalarsBuf.Bytes()), 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error on compressed points")
+	}
+}
+
+func TestMultiExpReaderAtG2(t *testing.T) {
+	const nbSamples = 73
+
+	points := make([]G2Affine, nbSamples)
+	scalars := make(fr.Vector, nbSamples)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := range points {
+		points[i].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+		scalars[i].SetRandom()
+	}
+	points[nbSamples/2].setInfinity()
+	scalars[nbSamples/3].SetZero()
+
+	var pointsBuf, scalarsBuf bytes.Buffer
+	if err := NewEncoder(&pointsBuf, RawEncoding()).Encode(points); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scalars.WriteTo(&scalarsBuf); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected G2Jac
+	if _, err := expected.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkSize := range []int{1, 7, nbSamples, 0} {
+		var res G2Jac
+		if _, err := res.MultiExpReaderAt(bytes.NewReader(pointsBuf.Bytes()), bytes.NewReader(scalarsBuf.Bytes()), chunkSize, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatalf("chunk size %d: out-of-core MultiExp doesn't match MultiExp", chunkSize)
+		}
+	}
+
+	// compressed points are rejected
+	var compressed bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(points); err != nil {
+		t.Fatal(err)
+	}
+	var res G2Jac
+	if _, err := res.MultiExpReaderAt(bytes.NewReader(compressed.Bytes()), bytes.NewReader(scalarsBuf.Bytes()), 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error on compressed points")
+	}
+}
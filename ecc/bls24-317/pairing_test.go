@@ -99,6 +99,38 @@ func TestPairing(t *testing.T) {
 		genR1,
 	))
 
+	properties.Property("[BLS24-317] MultiExp and the product of ExpGLV results must be the same in GT", prop.ForAll(
+		func(a GT, e1, e2 fr.Element) bool {
+			a = FinalExponentiation(&a)
+
+			bases := make([]GT, 5)
+			scalars := []fr.Element{e1, e2, e1, {}, e2}
+			scalars[2].Neg(&scalars[2])
+			bases[0].Set(&a)
+			for i := 1; i < len(bases); i++ {
+				bases[i].CyclotomicSquare(&bases[i-1])
+			}
+
+			var expected, tmp GT
+			var _e big.Int
+			expected.SetOne()
+			for i := range bases {
+				scalars[i].BigInt(&_e)
+				tmp.ExpGLV(bases[i], &_e)
+				expected.Mul(&expected, &tmp)
+			}
+
+			var res GT
+			if _, err := res.MultiExp(bases, scalars); err != nil {
+				return false
+			}
+			return res.Equal(&expected)
+		},
+		genA,
+		genR1,
+		genR2,
+	))
+
 	properties.Property("[BLS24-317] Expt(Expt) and Exp(t^2) should output the same result in the cyclotomic subgroup", prop.ForAll(
 		func(a GT) bool {
 			var b, c, d GT
@@ -198,6 +230,64 @@ func TestPairing(t *testing.T) {
 		genR2,
 	))
 
+	properties.Property("[BLS24-317] PairMixedQ should output the same result as Pair and leave the precomputed lines unchanged", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var ag1 G1Affine
+			var bg2 G2Affine
+
+			var abigint, bbigint big.Int
+
+			a.BigInt(&abigint)
+			b.BigInt(&bbigint)
+
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			P := []G1Affine{g1GenAff, ag1, ag1}
+			Q := []G2Affine{g2GenAff, bg2, g2GenAff}
+			lines := [][2][len(LoopCounter) - 1]LineEvaluationAff{
+				PrecomputeLines(Q[1]),
+				PrecomputeLines(Q[2]),
+			}
+
+			res1, _ := Pair(P, Q)
+			res2, _ := PairMixedQ(P[:1], Q[:1], P[1:], lines)
+			res3, _ := PairMixedQ(P[:1], Q[:1], P[1:], lines)
+			res4, _ := PairMixedQ(P, Q, nil, nil)
+			res5, _ := PairMixedQ(nil, nil, P[1:], lines)
+			res6, _ := Pair(P[1:], Q[1:])
+			var g1Inf G1Affine
+			res7, _ := PairMixedQ([]G1Affine{P[0], g1Inf}, []G2Affine{Q[0], bg2}, P[1:], lines)
+
+			return res1.Equal(&res2) && res1.Equal(&res3) && res1.Equal(&res4) && res5.Equal(&res6) && res1.Equal(&res7)
+		},
+		genR1,
+		genR2,
+	))
+
+	properties.Property("[BLS24-317] PairingCheckMixedQ should accept e(aP, Q)·e(-P, aQ) and reject mismatching sizes", prop.ForAll(
+		func(a fr.Element) bool {
+
+			var ag1, g1Neg G1Affine
+			var ag2 G2Affine
+			var abigint big.Int
+			a.BigInt(&abigint)
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+			ag2.ScalarMultiplication(&g2GenAff, &abigint)
+			g1Neg.Neg(&g1GenAff)
+			lines := [][2][len(LoopCounter) - 1]LineEvaluationAff{PrecomputeLines(ag2)}
+
+			ok, err := PairingCheckMixedQ([]G1Affine{ag1}, []G2Affine{g2GenAff}, []G1Affine{g1Neg}, lines)
+			if err != nil || !ok {
+				return false
+			}
+			_, err = PairingCheckMixedQ([]G1Affine{ag1}, nil, []G1Affine{g1Neg}, lines)
+			return err != nil
+		},
+		genR1,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -468,6 +558,68 @@ func BenchmarkMultiPair(b *testing.B) {
 	}
 }
 
+func BenchmarkPairMixedQ(b *testing.B) {
+
+	var g1GenAff G1Affine
+	var g2GenAff G2Affine
+
+	g1GenAff.FromJacobian(&g1Gen)
+	g2GenAff.FromJacobian(&g2Gen)
+
+	// e(A,B)·e(C,[δ])·e(D,[γ]) with [δ] and [γ] fixed
+	P := []G1Affine{g1GenAff, g1GenAff, g1GenAff}
+	Q := []G2Affine{g2GenAff, g2GenAff, g2GenAff}
+	lines := [][2][len(LoopCounter) - 1]LineEvaluationAff{
+		PrecomputeLines(Q[1]),
+		PrecomputeLines(Q[2]),
+	}
+
+	b.Run("Pair", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Pair(P, Q)
+		}
+	})
+	b.Run("PairMixedQ", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			PairMixedQ(P[:1], Q[:1], P[1:], lines)
+		}
+	})
+}
+
+func BenchmarkMultiExpGT(b *testing.B) {
+	var a GT
+	a.SetRandom()
+	a = FinalExponentiation(&a)
+
+	const n = 64
+	bases := make([]GT, n)
+	scalars := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		bases[i].CyclotomicSquare(&a)
+		a.Set(&bases[i])
+		scalars[i].SetRandom()
+	}
+
+	b.Run("ExpGLV", func(b *testing.B) {
+		var res, tmp GT
+		var e big.Int
+		for j := 0; j < b.N; j++ {
+			res.SetOne()
+			for i := 0; i < n; i++ {
+				scalars[i].BigInt(&e)
+				tmp.ExpGLV(bases[i], &e)
+				res.Mul(&res, &tmp)
+			}
+		}
+	})
+	b.Run("MultiExp", func(b *testing.B) {
+		var res GT
+		for j := 0; j < b.N; j++ {
+			res.MultiExp(bases, scalars)
+		}
+	})
+}
+
 func BenchmarkExpGT(b *testing.B) {
 
 	var a GT
@@ -0,0 +1,24 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package transcript provides a Fiat-Shamir transcript absorbing typed values (scalars, G1 and G2 points)
+// and squeezing any number of challenges, in any order.
+//
+// Two modes are available:
+//   - New: a hash based transcript (SHA-256 by default) with Merlin-style domain separation, for native verifiers;
+//   - NewSponge: a duplex sponge over MiMC, where scalars are absorbed and squeezed as field elements, for verifiers
+//     implemented in a SNARK circuit.
+package transcript
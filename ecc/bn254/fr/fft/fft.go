@@ -39,10 +39,20 @@ const butterflyThreshold = 16
 // FFT computes (recursively) the discrete Fourier transform of a and stores the result in a
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
+// if the domain cardinality is not a power of 2, decimation is ignored and input and output are in natural order
 func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option) {
 
 	opt := fftOptions(opts...)
 
+	if !isPowerOfTwo(domain.Cardinality) {
+		domain.fftMixed(a, false, opt)
+		return
+	}
+	if opt.fourStep && domain.Cardinality >= 4 {
+		domain.fourStep(a, decimation, false, opt)
+		return
+	}
+
 	// find the stage where we should stop spawning go routines in our recursive calls
 	// (ie when we have as many go routines running as we have available CPUs)
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(opt.nbTasks)))
@@ -115,10 +125,19 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, opts ...Option)
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // coset sets the shift of the fft (0 = no shift, standard fft)
-// len(a) must be a power of 2, and w must be a len(a)th root of unity in field F.
+// if the domain cardinality is not a power of 2, decimation is ignored and input and output are in natural order
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, opts ...Option) {
 	opt := fftOptions(opts...)
 
+	if !isPowerOfTwo(domain.Cardinality) {
+		domain.fftMixed(a, true, opt)
+		return
+	}
+	if opt.fourStep && domain.Cardinality >= 4 {
+		domain.fourStep(a, decimation, true, opt)
+		return
+	}
+
 	// find the stage where we should stop spawning go routines in our recursive calls
 	// (ie when we have as many go routines running as we have available CPUs)
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(opt.nbTasks)))
@@ -0,0 +1,35 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ipp provides inner pairing product arguments (Bünz, Maller, Mishra,
+// Tyagi and Vesely, "Proofs for inner pairing products and applications", ASIACRYPT 2021),
+// in the form used by SnarkPack (Gailly, Maller and Nitulescu, FC 2022) to aggregate
+// pairing equations.
+//
+// The SRS holds the powers of two secrets a and b in G₁ and G₂. Vectors A ∈ G₁ⁿ and
+// B ∈ G₂ⁿ are committed with the keys v = ([aⁱ]G₂, [bⁱ]G₂) and w = ([aⁿ⁺ⁱ]G₁, [bⁿ⁺ⁱ]G₁):
+//
+//	T = ∏ e(Aᵢ, [aⁱ]G₂)·e([aⁿ⁺ⁱ]G₁, Bᵢ)
+//	U = ∏ e(Aᵢ, [bⁱ]G₂)·e([bⁿ⁺ⁱ]G₁, Bᵢ)
+//
+// and vectors C ∈ G₁ⁿ with the key v only.
+//
+// Given a challenge r, TIPP proves that Z = ∏ e(Aᵢ, Bᵢ)^{rⁱ} for committed A and B, and
+// MIPP proves that Z = ∑ [rⁱ]Cᵢ for a committed C. Both run log₂(n) rounds of a
+// generalized inner product argument (GIPA), with challenges drawn from a Fiat-Shamir
+// transcript, and end with KZG openings showing that the folded keys are well formed.
+// Verification costs O(log n) exponentiations in GT and a constant number of pairings.
+package ipp
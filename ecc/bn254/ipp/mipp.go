@@ -0,0 +1,237 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipp
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/transcript"
+)
+
+// MIPPProof proves that Z = ∑ [rⁱ]Cᵢ for a vector C ∈ G₁ᵐ committed with CommitG1.
+//
+// In round j, the vectors are split in halves L and R and folded with a challenge xⱼ as
+// C' = C_L + [xⱼ]C_R, r' = r_L + xⱼ⁻¹·r_R and v' = v_L + [xⱼ⁻¹]v_R.
+type MIPPProof struct {
+	Z        bn254.G1Affine    // ∑ [rⁱ]Cᵢ
+	ZL, ZR   []bn254.G1Affine  // ⟨C_R, r_L⟩ and ⟨C_L, r_R⟩ in each round
+	CL, CR   []Commitment      // commitments to C_R and C_L in each round
+	C        bn254.G1Affine    // folded C
+	V        [2]bn254.G2Affine // folded v, [f_v(a)]G₂ and [f_v(b)]G₂
+	OpeningV [2]bn254.G2Affine // KZG openings of V
+}
+
+// ProveMIPP proves that Z = ∑ [rⁱ]Cᵢ, where com = CommitG1(C, pk).
+//
+// r should be derived from tr after binding com; ProveMIPP then appends com, Z, r and the
+// messages of each round to tr. The verifier must use a transcript in the same state.
+func ProveMIPP(C []bn254.G1Affine, com *Commitment, r fr.Element, pk *ProvingKey, tr *transcript.Transcript) (MIPPProof, error) {
+	var proof MIPPProof
+	n, m := len(pk.G2[0]), len(C)
+	if !validSize(m, m, n) {
+		return proof, ErrInvalidSize
+	}
+
+	c := C
+	s := powers(r, m)
+	v := [2][]bn254.G2Affine{pk.G2[0][:m], pk.G2[1][:m]}
+
+	if _, err := proof.Z.MultiExp(c, s, ecc.MultiExpConfig{}); err != nil {
+		return proof, err
+	}
+	if err := bindMIPP(tr, com, &proof.Z, &r); err != nil {
+		return proof, err
+	}
+
+	nbRounds := bits.TrailingZeros(uint(m))
+	proof.ZL = make([]bn254.G1Affine, nbRounds)
+	proof.ZR = make([]bn254.G1Affine, nbRounds)
+	proof.CL = make([]Commitment, nbRounds)
+	proof.CR = make([]Commitment, nbRounds)
+	xInv := make([]fr.Element, nbRounds)
+
+	for j := 0; j < nbRounds; j++ {
+		h := len(c) / 2
+		cL, cR, sL, sR := c[:h], c[h:], s[:h], s[h:]
+		if _, err := proof.ZL[j].MultiExp(cR, sL, ecc.MultiExpConfig{}); err != nil {
+			return proof, err
+		}
+		if _, err := proof.ZR[j].MultiExp(cL, sR, ecc.MultiExpConfig{}); err != nil {
+			return proof, err
+		}
+		res, err := pairAll(
+			[][]bn254.G1Affine{cR, cR, cL, cL},
+			[][]bn254.G2Affine{v[0][:h], v[1][:h], v[0][h:], v[1][h:]},
+		)
+		if err != nil {
+			return proof, err
+		}
+		proof.CL[j] = Commitment{T: res[0], U: res[1]}
+		proof.CR[j] = Commitment{T: res[2], U: res[3]}
+
+		var x fr.Element
+		if x, xInv[j], err = bindMIPPRound(tr, &proof, j); err != nil {
+			return proof, err
+		}
+
+		c = foldG1(cL, cR, x)
+		s = foldScalars(sL, sR, xInv[j])
+		for k := 0; k < 2; k++ {
+			v[k] = foldG2(v[k][:h], v[k][h:], xInv[j])
+		}
+	}
+
+	proof.C = c[0]
+	proof.V = [2]bn254.G2Affine{v[0][0], v[1][0]}
+	z, err := bindMIPPFinal(tr, &proof)
+	if err != nil {
+		return proof, err
+	}
+
+	// open the folded key at z
+	fv := foldedKey(xInv)
+	for k := 0; k < 2; k++ {
+		if proof.OpeningV[k], err = openG2(fv, z, pk.G2[k]); err != nil {
+			return proof, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyMIPP verifies a proof that proof.Z = ∑ [rⁱ]Cᵢ, where com is the commitment to C.
+// tr must be in the same state as the prover's transcript was.
+func VerifyMIPP(com *Commitment, r fr.Element, proof *MIPPProof, vk *VerifyingKey, tr *transcript.Transcript) error {
+	nbRounds := len(proof.ZL)
+	if !validRounds(nbRounds, vk.Size) || len(proof.ZR) != nbRounds || len(proof.CL) != nbRounds || len(proof.CR) != nbRounds {
+		return ErrInvalidProof
+	}
+	if !inGT(proof.CL) || !inGT(proof.CR) {
+		return ErrInvalidProof
+	}
+
+	if err := bindMIPP(tr, com, &proof.Z, &r); err != nil {
+		return err
+	}
+
+	// fold the commitment and the inner product with the cross terms of each round
+	var Z, t bn254.G1Jac
+	var b big.Int
+	Z.FromAffine(&proof.Z)
+	T, U := com.T, com.U
+	xInv := make([]fr.Element, nbRounds)
+	for j := 0; j < nbRounds; j++ {
+		x, xi, err := bindMIPPRound(tr, proof, j)
+		if err != nil {
+			return err
+		}
+		xInv[j] = xi
+		Z.AddAssign(t.ScalarMultiplicationAffine(&proof.ZL[j], x.BigInt(&b)))
+		Z.AddAssign(t.ScalarMultiplicationAffine(&proof.ZR[j], xi.BigInt(&b)))
+		if err = foldGT(&T, &proof.CL[j].T, &proof.CR[j].T, x, xi); err != nil {
+			return err
+		}
+		if err = foldGT(&U, &proof.CL[j].U, &proof.CR[j].U, x, xi); err != nil {
+			return err
+		}
+	}
+	z, err := bindMIPPFinal(tr, proof)
+	if err != nil {
+		return err
+	}
+
+	// the folded vectors of size 1 must satisfy the relations; the folded r is f_v(r)
+	rFolded := evalFoldedKey(xInv, r)
+	t.ScalarMultiplicationAffine(&proof.C, rFolded.BigInt(&b))
+	if !t.Equal(&Z) {
+		return ErrVerifyMIPP
+	}
+	res, err := pairAll(
+		[][]bn254.G1Affine{{proof.C}, {proof.C}},
+		[][]bn254.G2Affine{{proof.V[0]}, {proof.V[1]}},
+	)
+	if err != nil {
+		return err
+	}
+	if !res[0].Equal(&T) || !res[1].Equal(&U) {
+		return ErrVerifyMIPP
+	}
+
+	// and the folded key must be well formed
+	yV := evalFoldedKey(xInv, z)
+	ok, err := vk.verifyKeys(z, yV, &proof.V, &proof.OpeningV, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyMIPP
+	}
+	return nil
+}
+
+// foldScalars returns L + x·R
+func foldScalars(L, R []fr.Element, x fr.Element) []fr.Element {
+	res := make([]fr.Element, len(L))
+	for i := range res {
+		res[i].Mul(&R[i], &x).Add(&res[i], &L[i])
+	}
+	return res
+}
+
+// bindMIPP appends the statement of a MIPP proof to the transcript
+func bindMIPP(tr *transcript.Transcript, com *Commitment, Z *bn254.G1Affine, r *fr.Element) error {
+	if err := appendGT(tr, "com", com.T, com.U); err != nil {
+		return err
+	}
+	if err := tr.AppendG1("Z", Z); err != nil {
+		return err
+	}
+	return tr.AppendScalar("r", r)
+}
+
+// bindMIPPRound appends the messages of round j of a MIPP proof to the transcript, and
+// returns the round challenge and its inverse
+func bindMIPPRound(tr *transcript.Transcript, proof *MIPPProof, j int) (x, xInv fr.Element, err error) {
+	if err = tr.AppendG1("ZL", &proof.ZL[j]); err != nil {
+		return
+	}
+	if err = tr.AppendG1("ZR", &proof.ZR[j]); err != nil {
+		return
+	}
+	if err = appendGT(tr, "round", proof.CL[j].T, proof.CL[j].U, proof.CR[j].T, proof.CR[j].U); err != nil {
+		return
+	}
+	return challenge(tr)
+}
+
+// bindMIPPFinal appends the folded values of a MIPP proof to the transcript, and returns
+// the challenge at which the folded key is opened
+func bindMIPPFinal(tr *transcript.Transcript, proof *MIPPProof) (fr.Element, error) {
+	if err := tr.AppendG1("C", &proof.C); err != nil {
+		return fr.Element{}, err
+	}
+	for k := 0; k < 2; k++ {
+		if err := tr.AppendG2("V", &proof.V[k]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return tr.ChallengeScalar("z")
+}
@@ -0,0 +1,253 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipp
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/transcript"
+)
+
+// TIPPProof proves that Z = ∏ e(Aᵢ, Bᵢ)^{rⁱ} for vectors A ∈ G₁ᵐ, B ∈ G₂ᵐ committed with CommitPair.
+//
+// The prover works on B' = ([rⁱ]Bᵢ)ᵢ and w' = ([r⁻ⁱ]wᵢ)ᵢ, which have the same commitment as (A, B).
+// In round j, the vectors are split in halves L and R and folded with a challenge xⱼ as
+// A' = A_L + [xⱼ]A_R, B' = B_L + [xⱼ⁻¹]B_R, v' = v_L + [xⱼ⁻¹]v_R and w' = w_L + [xⱼ]w_R.
+type TIPPProof struct {
+	Z        bn254.GT          // ∏ e(Aᵢ, Bᵢ)^{rⁱ}
+	ZL, ZR   []bn254.GT        // e(A_R, B_L) and e(A_L, B_R) in each round
+	CL, CR   []Commitment      // commitments to (A_R, B_L) and (A_L, B_R) in each round
+	A        bn254.G1Affine    // folded A
+	B        bn254.G2Affine    // folded B
+	V        [2]bn254.G2Affine // folded v, [f_v(a)]G₂ and [f_v(b)]G₂
+	W        [2]bn254.G1Affine // folded w, [f_w(a)]G₁ and [f_w(b)]G₁
+	OpeningV [2]bn254.G2Affine // KZG openings of V
+	OpeningW [2]bn254.G1Affine // KZG openings of W
+}
+
+// ProveTIPP proves that Z = ∏ e(Aᵢ, Bᵢ)^{rⁱ}, where com = CommitPair(A, B, pk).
+//
+// r should be derived from tr after binding com; ProveTIPP then appends com, Z, r and the
+// messages of each round to tr. The verifier must use a transcript in the same state.
+func ProveTIPP(A []bn254.G1Affine, B []bn254.G2Affine, com *Commitment, r fr.Element, pk *ProvingKey, tr *transcript.Transcript) (TIPPProof, error) {
+	var proof TIPPProof
+	n, m := len(pk.G2[0]), len(A)
+	if !validSize(m, len(B), n) {
+		return proof, ErrInvalidSize
+	}
+	if r.IsZero() {
+		return proof, ErrZeroChallenge
+	}
+	var rInv fr.Element
+	rInv.Inverse(&r)
+
+	// rescale B and w, so that Z = ⟨A, B'⟩ and the commitment is unchanged
+	a := A
+	b := scaleG2(B, powers(r, m))
+	rInvPowers := powers(rInv, m)
+	v := [2][]bn254.G2Affine{pk.G2[0][:m], pk.G2[1][:m]}
+	w := [2][]bn254.G1Affine{scaleG1(pk.G1[0][n:n+m], rInvPowers), scaleG1(pk.G1[1][n:n+m], rInvPowers)}
+
+	var err error
+	if proof.Z, err = bn254.Pair(a, b); err != nil {
+		return proof, err
+	}
+	if err = bindTIPP(tr, com, &proof.Z, &r); err != nil {
+		return proof, err
+	}
+
+	nbRounds := bits.TrailingZeros(uint(m))
+	proof.ZL = make([]bn254.GT, nbRounds)
+	proof.ZR = make([]bn254.GT, nbRounds)
+	proof.CL = make([]Commitment, nbRounds)
+	proof.CR = make([]Commitment, nbRounds)
+	x := make([]fr.Element, nbRounds)
+	xInv := make([]fr.Element, nbRounds)
+
+	for j := 0; j < nbRounds; j++ {
+		h := len(a) / 2
+		aL, aR, bL, bR := a[:h], a[h:], b[:h], b[h:]
+		res, err := pairAll(
+			[][]bn254.G1Affine{
+				aR, aL,
+				concatG1(aR, w[0][h:]), concatG1(aR, w[1][h:]),
+				concatG1(aL, w[0][:h]), concatG1(aL, w[1][:h]),
+			},
+			[][]bn254.G2Affine{
+				bL, bR,
+				concatG2(v[0][:h], bL), concatG2(v[1][:h], bL),
+				concatG2(v[0][h:], bR), concatG2(v[1][h:], bR),
+			},
+		)
+		if err != nil {
+			return proof, err
+		}
+		proof.ZL[j], proof.ZR[j] = res[0], res[1]
+		proof.CL[j] = Commitment{T: res[2], U: res[3]}
+		proof.CR[j] = Commitment{T: res[4], U: res[5]}
+
+		if err = appendGT(tr, "round", res...); err != nil {
+			return proof, err
+		}
+		if x[j], xInv[j], err = challenge(tr); err != nil {
+			return proof, err
+		}
+
+		a = foldG1(aL, aR, x[j])
+		b = foldG2(bL, bR, xInv[j])
+		for k := 0; k < 2; k++ {
+			v[k] = foldG2(v[k][:h], v[k][h:], xInv[j])
+			w[k] = foldG1(w[k][:h], w[k][h:], x[j])
+		}
+	}
+
+	proof.A, proof.B = a[0], b[0]
+	proof.V = [2]bn254.G2Affine{v[0][0], v[1][0]}
+	proof.W = [2]bn254.G1Affine{w[0][0], w[1][0]}
+	z, err := bindTIPPFinal(tr, &proof)
+	if err != nil {
+		return proof, err
+	}
+
+	// open the folded keys at z
+	fv := foldedKey(xInv)
+	fw := append(make([]fr.Element, n, n+m), foldedKey(wCoefficients(x, rInv))...)
+	for k := 0; k < 2; k++ {
+		if proof.OpeningV[k], err = openG2(fv, z, pk.G2[k]); err != nil {
+			return proof, err
+		}
+		if proof.OpeningW[k], err = openG1(fw, z, pk.G1[k]); err != nil {
+			return proof, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyTIPP verifies a proof that proof.Z = ∏ e(Aᵢ, Bᵢ)^{rⁱ}, where com is the commitment to A and B.
+// tr must be in the same state as the prover's transcript was.
+func VerifyTIPP(com *Commitment, r fr.Element, proof *TIPPProof, vk *VerifyingKey, tr *transcript.Transcript) error {
+	nbRounds := len(proof.ZL)
+	if !validRounds(nbRounds, vk.Size) || len(proof.ZR) != nbRounds || len(proof.CL) != nbRounds || len(proof.CR) != nbRounds {
+		return ErrInvalidProof
+	}
+	if !proof.Z.IsInSubGroup() || !inGT(proof.CL) || !inGT(proof.CR) {
+		return ErrInvalidProof
+	}
+	for j := 0; j < nbRounds; j++ {
+		if !proof.ZL[j].IsInSubGroup() || !proof.ZR[j].IsInSubGroup() {
+			return ErrInvalidProof
+		}
+	}
+	if r.IsZero() {
+		return ErrZeroChallenge
+	}
+	var rInv fr.Element
+	rInv.Inverse(&r)
+
+	if err := bindTIPP(tr, com, &proof.Z, &r); err != nil {
+		return err
+	}
+
+	// fold the commitment and the inner product with the cross terms of each round
+	Z, T, U := proof.Z, com.T, com.U
+	x := make([]fr.Element, nbRounds)
+	xInv := make([]fr.Element, nbRounds)
+	for j := 0; j < nbRounds; j++ {
+		err := appendGT(tr, "round", proof.ZL[j], proof.ZR[j], proof.CL[j].T, proof.CL[j].U, proof.CR[j].T, proof.CR[j].U)
+		if err != nil {
+			return err
+		}
+		if x[j], xInv[j], err = challenge(tr); err != nil {
+			return err
+		}
+		if err = foldGT(&Z, &proof.ZL[j], &proof.ZR[j], x[j], xInv[j]); err != nil {
+			return err
+		}
+		if err = foldGT(&T, &proof.CL[j].T, &proof.CR[j].T, x[j], xInv[j]); err != nil {
+			return err
+		}
+		if err = foldGT(&U, &proof.CL[j].U, &proof.CR[j].U, x[j], xInv[j]); err != nil {
+			return err
+		}
+	}
+	z, err := bindTIPPFinal(tr, proof)
+	if err != nil {
+		return err
+	}
+
+	// the folded vectors of size 1 must satisfy the relations
+	res, err := pairAll(
+		[][]bn254.G1Affine{{proof.A}, {proof.A, proof.W[0]}, {proof.A, proof.W[1]}},
+		[][]bn254.G2Affine{{proof.B}, {proof.V[0], proof.B}, {proof.V[1], proof.B}},
+	)
+	if err != nil {
+		return err
+	}
+	if !res[0].Equal(&Z) || !res[1].Equal(&T) || !res[2].Equal(&U) {
+		return ErrVerifyTIPP
+	}
+
+	// and the folded keys must be well formed
+	yV := evalFoldedKey(xInv, z)
+	yW := evalFoldedKey(wCoefficients(x, rInv), z)
+	var zn fr.Element
+	zn.Exp(z, new(big.Int).SetUint64(vk.Size))
+	yW.Mul(&yW, &zn)
+	ok, err := vk.verifyKeys(z, yV, &proof.V, &proof.OpeningV, &yW, &proof.W, &proof.OpeningW)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyTIPP
+	}
+	return nil
+}
+
+// bindTIPP appends the statement of a TIPP proof to the transcript
+func bindTIPP(tr *transcript.Transcript, com *Commitment, Z *bn254.GT, r *fr.Element) error {
+	if err := appendGT(tr, "com", com.T, com.U); err != nil {
+		return err
+	}
+	if err := appendGT(tr, "Z", *Z); err != nil {
+		return err
+	}
+	return tr.AppendScalar("r", r)
+}
+
+// bindTIPPFinal appends the folded values of a TIPP proof to the transcript, and returns
+// the challenge at which the folded keys are opened
+func bindTIPPFinal(tr *transcript.Transcript, proof *TIPPProof) (fr.Element, error) {
+	if err := tr.AppendG1("A", &proof.A); err != nil {
+		return fr.Element{}, err
+	}
+	if err := tr.AppendG2("B", &proof.B); err != nil {
+		return fr.Element{}, err
+	}
+	for k := 0; k < 2; k++ {
+		if err := tr.AppendG2("V", &proof.V[k]); err != nil {
+			return fr.Element{}, err
+		}
+		if err := tr.AppendG1("W", &proof.W[k]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return tr.ChallengeScalar("z")
+}
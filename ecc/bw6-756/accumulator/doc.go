@@ -0,0 +1,26 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package accumulator provides a dynamic pairing-based set accumulator with
+// constant-size membership and non-membership witnesses (Nguyen, "Accumulators
+// from Bilinear Pairings and Applications", CT-RSA 2005; non-membership
+// witnesses after Damgård and Triandopoulos, ePrint 2008/538).
+//
+// The accumulator of a set X ⊂ 𝔽ᵣ is [f(s)]G₁, where f(X) = ∏_{x ∈ X} (X + x) and s
+// is the secret key of the manager. Anyone can verify witnesses with the public key
+// ([s]G₂) and update them after additions and deletions: a batch of m updates
+// costs O(m) field operations and a multi-exponentiation of size m+1.
+package accumulator
@@ -171,6 +171,66 @@ func (vector *Vector) ReadFrom(r io.Reader) (int64, error) {
 	return n, nil
 }
 
+// ReadElementsAt decodes len(vector) elements of a Vector encoded with WriteTo, starting at its
+// offset-th element. The elements are decoded in place, without an intermediate buffer, so that large
+// vectors can be processed by chunks (for instance from a file) in bounded memory.
+func (vector Vector) ReadElementsAt(r io.ReaderAt, offset int64) error {
+	if len(vector) == 0 {
+		return nil
+	}
+	bSlice := unsafe.Slice((*byte)(unsafe.Pointer(&vector[0])), len(vector)*Bytes)
+	if _, err := r.ReadAt(bSlice, 4+offset*Bytes); err != nil {
+		return err
+	}
+
+	var cptErrors uint64
+	execute(len(vector), func(start, end int) {
+		var z Element
+		for i := start; i < end; i++ {
+			b := bSlice[i*Bytes : (i+1)*Bytes]
+			z[0] = binary.BigEndian.Uint64(b[40:48])
+			z[1] = binary.BigEndian.Uint64(b[32:40])
+			z[2] = binary.BigEndian.Uint64(b[24:32])
+			z[3] = binary.BigEndian.Uint64(b[16:24])
+			z[4] = binary.BigEndian.Uint64(b[8:16])
+			z[5] = binary.BigEndian.Uint64(b[0:8])
+
+			if !z.smallerThanModulus() {
+				atomic.AddUint64(&cptErrors, 1)
+				return
+			}
+			z.toMont()
+			vector[i] = z
+		}
+	})
+
+	if cptErrors > 0 {
+		return fmt.Errorf("read elements: %d elements failed validation", cptErrors)
+	}
+	return nil
+}
+
+// WriteElementsAt encodes vector at the offset-th element of a Vector encoded with WriteTo.
+// The elements are encoded by chunks, so that the memory used does not depend on len(vector).
+func (vector Vector) WriteElementsAt(w io.WriterAt, offset int64) error {
+	const chunkSize = 1024
+	var buf [chunkSize * Bytes]byte
+	for start := 0; start < len(vector); start += chunkSize {
+		end := start + chunkSize
+		if end > len(vector) {
+			end = len(vector)
+		}
+		b := buf[:(end-start)*Bytes]
+		for i := start; i < end; i++ {
+			BigEndian.PutElement((*[Bytes]byte)(b[(i-start)*Bytes:]), vector[i])
+		}
+		if _, err := w.WriteAt(b, 4+(offset+int64(start))*Bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // String implements fmt.Stringer interface
 func (vector Vector) String() string {
 	var sbb strings.Builder
@@ -0,0 +1,34 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package edwards25519 provides the twisted Edwards curve birationally equivalent
+// to Curve25519 (RFC 7748), with the same point types as the companion curves in
+// ecc/<curve>/twistededwards.
+//
+// edwards25519: a twisted Edwards curve with
+//
+//	𝔽p: p=2²⁵⁵-19
+//	𝔽r: r=2²⁵²+27742317777372353535851937790883648493 (order of the prime subgroup)
+//	(E/𝔽p): -x²+y²=1+d·x²y², d=-121665/121666
+//	cofactor: 8
+//
+// Points are encoded following https://tools.ietf.org/html/rfc8032#section-5.1.2,
+// so that PointAffine.Bytes() and PointAffine.SetBytes() are compatible with crypto/ed25519.
+// The eddsa sub-package implements Ed25519 and the ristretto255 sub-package the
+// prime-order group of https://www.rfc-editor.org/rfc/rfc9496.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
+package edwards25519
@@ -0,0 +1,23 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eddsa provides the Ed25519 signature scheme of RFC 8032 on edwards25519.
+//
+// Keys and signatures use the same binary layout as crypto/ed25519, and
+// signatures produced by either package verify with the other one.
+//
+// # See also
+//
+// https://tools.ietf.org/html/rfc8032
+package eddsa
@@ -0,0 +1,277 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519"
+	"github.com/consensys/gnark-crypto/ecc/edwards25519/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+var errHashNotSupported = errors.New("hFunc must be nil, Ed25519 hashes with SHA-512")
+var errWrongSeedSize = errors.New("seed must be 32 bytes")
+var errLengthMismatch = errors.New("public keys, messages and signatures must have the same length")
+
+const (
+	sizeFr         = fr.Bytes
+	sizeSeed       = 32
+	sizePublicKey  = sizeFr
+	sizeSignature  = 2 * sizeFr
+	sizePrivateKey = sizeSeed + sizePublicKey
+)
+
+// PublicKey eddsa signature object
+// cf https://tools.ietf.org/html/rfc8032#section-5.1.5 for notation
+type PublicKey struct {
+	A edwards25519.PointAffine
+}
+
+// PrivateKey private key of an eddsa instance
+type PrivateKey struct {
+	PublicKey PublicKey      // copy of the associated public key
+	seed      [sizeSeed]byte // private key as defined in RFC 8032
+	scalar    [sizeFr]byte   // secret (pruned) scalar, in big Endian
+	prefix    [32]byte       // second half of SHA-512(seed), used to derive the nonces
+}
+
+// Signature represents an eddsa signature
+// cf https://tools.ietf.org/html/rfc8032#section-5.1.6 for notation
+type Signature struct {
+	R edwards25519.PointAffine
+	S [sizeFr]byte // in little Endian, as in RFC 8032
+}
+
+// GenerateKey generates a public and private key pair
+// from 32 bytes read from r.
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	var seed [sizeSeed]byte
+	if _, err := io.ReadFull(r, seed[:]); err != nil {
+		return nil, err
+	}
+	return NewKeyFromSeed(seed[:])
+}
+
+// NewKeyFromSeed computes the key pair associated to a 32 bytes seed,
+// see https://tools.ietf.org/html/rfc8032#section-5.1.5.
+// It matches crypto/ed25519.NewKeyFromSeed.
+func NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != sizeSeed {
+		return nil, errWrongSeedSize
+	}
+	c := edwards25519.GetEdwardsCurve()
+
+	var priv PrivateKey
+	copy(priv.seed[:], seed)
+
+	h := sha512.Sum512(seed)
+	copy(priv.prefix[:], h[32:])
+
+	// prune the key
+	// https://tools.ietf.org/html/rfc8032#section-5.1.5, key generation
+	h[0] &= 0xF8
+	h[31] &= 0x7F
+	h[31] |= 0x40
+
+	// reverse first bytes because setBytes interpret stream as big endian
+	// but in eddsa specs s is the first 32 bytes in little endian
+	for i, j := 0, sizeFr-1; i < sizeFr; i, j = i+1, j-1 {
+		priv.scalar[i] = h[j]
+	}
+
+	var bScalar big.Int
+	bScalar.SetBytes(priv.scalar[:])
+	priv.PublicKey.A.ScalarMultiplication(&c.Base, &bScalar)
+
+	return &priv, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs a message following https://tools.ietf.org/html/rfc8032#section-5.1.6 (pure Ed25519).
+// Ed25519 is deterministic and uses SHA-512, so hFunc must be nil.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc != nil {
+		return nil, errHashNotSupported
+	}
+
+	curveParams := edwards25519.GetEdwardsCurve()
+
+	var res Signature
+
+	// r = SHA-512(prefix || M) mod l
+	h := sha512.New()
+	h.Write(privKey.prefix[:])
+	h.Write(message)
+	var r fr.Element
+	setLittleEndianWide(&r, h.Sum(nil))
+
+	// R = [r]B
+	var bR big.Int
+	r.BigInt(&bR)
+	res.R.ScalarMultiplication(&curveParams.Base, &bR)
+
+	// k = SHA-512(R || A || M) mod l
+	encR := res.R.Bytes()
+	k := challenge(encR[:], &privKey.PublicKey, message)
+
+	// S = r + k*s mod l
+	var s, S fr.Element
+	s.SetBytes(privKey.scalar[:])
+	S.Mul(&k, &s).Add(&S, &r)
+	fr.LittleEndian.PutElement(&res.S, S)
+
+	return res.Bytes(), nil
+}
+
+// Verify verifies an eddsa signature following https://tools.ietf.org/html/rfc8032#section-5.1.7,
+// with the cofactorless equation [S]B = R + [k]A used by crypto/ed25519.
+// Ed25519 uses SHA-512, so hFunc must be nil.
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	if hFunc != nil {
+		return false, errHashNotSupported
+	}
+
+	curveParams := edwards25519.GetEdwardsCurve()
+
+	// Deserialize the signature
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+
+	k := challenge(sigBin[:sizeFr], pub, message)
+
+	// [S]B - [k]A must encode to R
+	var S fr.Element
+	S, _ = fr.LittleEndian.Element(&sig.S) // S < l was checked by sig.SetBytes
+	var bS, bk big.Int
+	S.BigInt(&bS)
+	k.BigInt(&bk)
+
+	var lhs, kA, A edwards25519.PointExtended
+	lhs.FromAffine(&curveParams.Base)
+	lhs.ScalarMultiplication(&lhs, &bS)
+	A.FromAffine(&pub.A)
+	kA.ScalarMultiplication(&A, &bk)
+	kA.Neg(&kA)
+	lhs.Add(&lhs, &kA)
+
+	var R edwards25519.PointAffine
+	R.FromExtended(&lhs)
+	encR := R.Bytes()
+
+	return subtle.ConstantTimeCompare(encR[:], sigBin[:sizeFr]) == 1, nil
+}
+
+// BatchVerify verifies signatures[i] on messages[i] for publicKeys[i], for all i.
+//
+// It checks a random linear combination of the cofactored equations
+// [8][S]B = [8]R + [8][k]A, see https://tools.ietf.org/html/rfc8032#section-8.8.
+// Signatures accepted by Verify are always accepted by BatchVerify; a batch
+// may additionally accept signatures whose R or A has a small order component,
+// that Verify rejects.
+func BatchVerify(publicKeys []PublicKey, messages, signatures [][]byte) (bool, error) {
+	if len(publicKeys) != len(messages) || len(publicKeys) != len(signatures) {
+		return false, errLengthMismatch
+	}
+
+	curveParams := edwards25519.GetEdwardsCurve()
+
+	// -[Σ zᵢSᵢ]B + Σ [zᵢ]Rᵢ + Σ [zᵢkᵢ]Aᵢ, computed with a single multi-exponentiation
+	n := len(publicKeys)
+	points := make([]edwards25519.PointAffine, 2*n+1)
+	scalars := make([]fr.Element, 2*n+1)
+
+	var zBytes [16]byte
+	for i := range publicKeys {
+		var sig Signature
+		if _, err := sig.SetBytes(signatures[i]); err != nil {
+			return false, err
+		}
+		k := challenge(signatures[i][:sizeFr], &publicKeys[i], messages[i])
+
+		// 128-bit random coefficient zᵢ
+		if _, err := rand.Read(zBytes[:]); err != nil {
+			return false, err
+		}
+		var z fr.Element
+		z.SetBytes(zBytes[:])
+
+		var S fr.Element
+		S, _ = fr.LittleEndian.Element(&sig.S) // S < l was checked by sig.SetBytes
+		S.Mul(&S, &z)
+		scalars[0].Sub(&scalars[0], &S)
+
+		points[1+i] = sig.R
+		scalars[1+i] = z
+		points[1+n+i] = publicKeys[i].A
+		scalars[1+n+i].Mul(&z, &k)
+	}
+	points[0] = curveParams.Base
+
+	var sum edwards25519.PointExtended
+	if _, err := sum.MultiExp(points, scalars); err != nil {
+		return false, err
+	}
+	sum.MultiplyByCofactor(&sum)
+
+	return sum.IsZero(), nil
+}
+
+// challenge returns k = SHA-512(R || A || M) mod l
+func challenge(encR []byte, pub *PublicKey, message []byte) fr.Element {
+	encA := pub.A.Bytes()
+	h := sha512.New()
+	h.Write(encR)
+	h.Write(encA[:])
+	h.Write(message)
+
+	var k fr.Element
+	setLittleEndianWide(&k, h.Sum(nil))
+	return k
+}
+
+// setLittleEndianWide sets z to the little endian integer in buf, reduced mod l
+func setLittleEndianWide(z *fr.Element, buf []byte) {
+	be := make([]byte, len(buf))
+	for i, j := 0, len(buf)-1; i < len(buf); i, j = i+1, j-1 {
+		be[i] = buf[j]
+	}
+	z.SetBytes(be)
+}
@@ -0,0 +1,287 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func Example() {
+	// create a eddsa key pair
+	privateKey, _ := GenerateKey(crand.Reader)
+	publicKey := privateKey.PublicKey
+
+	msg := []byte("hello, world")
+
+	// sign the message
+	signature, _ := privateKey.Sign(msg, nil)
+
+	// verifies signature
+	isValid, _ := publicKey.Verify(signature, msg, nil)
+	if !isValid {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+// https://tools.ietf.org/html/rfc8032#section-7.1
+func TestRFC8032(t *testing.T) {
+	t.Parallel()
+
+	vectors := []struct {
+		seed, pub, msg, sig string
+	}{
+		{
+			seed: "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pub:  "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			msg:  "",
+			sig:  "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			seed: "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pub:  "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			msg:  "72",
+			sig:  "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+		{
+			seed: "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			pub:  "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+			msg:  "af82",
+			sig:  "6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+		},
+	}
+
+	for i, v := range vectors {
+		seed, _ := hex.DecodeString(v.seed)
+		msg, _ := hex.DecodeString(v.msg)
+
+		privKey, err := NewKeyFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(privKey.PublicKey.Bytes()) != v.pub {
+			t.Fatalf("vector %d: wrong public key", i)
+		}
+		sig, err := privKey.Sign(msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("vector %d: wrong signature", i)
+		}
+		ok, err := privKey.PublicKey.Verify(sig, msg, nil)
+		if err != nil || !ok {
+			t.Fatalf("vector %d: signature should verify", i)
+		}
+	}
+}
+
+func TestStdlibCompatibility(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 20; i++ {
+		seed := make([]byte, ed25519.SeedSize)
+		msg := make([]byte, i*7)
+		rand.Read(seed) //#nosec G404 weak rng is fine here
+		rand.Read(msg)  //#nosec G404 weak rng is fine here
+
+		privKey, err := NewKeyFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdPrivKey := ed25519.NewKeyFromSeed(seed)
+
+		// same keys
+		if !bytes.Equal(privKey.Bytes(), stdPrivKey) {
+			t.Fatal("private keys don't match crypto/ed25519")
+		}
+		if !bytes.Equal(privKey.PublicKey.Bytes(), stdPrivKey.Public().(ed25519.PublicKey)) {
+			t.Fatal("public keys don't match crypto/ed25519")
+		}
+
+		// same (deterministic) signatures
+		sig, err := privKey.Sign(msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig, ed25519.Sign(stdPrivKey, msg)) {
+			t.Fatal("signatures don't match crypto/ed25519")
+		}
+
+		// verify each other's signatures
+		if !ed25519.Verify(stdPrivKey.Public().(ed25519.PublicKey), msg, sig) {
+			t.Fatal("crypto/ed25519 rejected the signature")
+		}
+		var pk PublicKey
+		if _, err := pk.SetBytes(stdPrivKey.Public().(ed25519.PublicKey)); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := pk.Verify(ed25519.Sign(stdPrivKey, msg), msg, nil)
+		if err != nil || !ok {
+			t.Fatal("crypto/ed25519 signature rejected")
+		}
+
+		// wrong message
+		ok, _ = pk.Verify(sig, append(msg, 1), nil)
+		if ok {
+			t.Fatal("signature of another message accepted")
+		}
+	}
+}
+
+func TestNonMalleability(t *testing.T) {
+	t.Parallel()
+
+	privKey, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	sig, _ := privKey.Sign(msg, nil)
+
+	// buffer too big
+	t.Run("buffer_overflow", func(t *testing.T) {
+		var s Signature
+		if _, err := s.SetBytes(append(sig, 0)); err != errWrongSize {
+			t.Fatal("should raise wrong size error")
+		}
+	})
+
+	// S+l is rejected
+	t.Run("S_overflow", func(t *testing.T) {
+		l, _ := hex.DecodeString("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+		malleated := make([]byte, len(sig))
+		copy(malleated, sig)
+		var carry uint16
+		for i := 0; i < sizeFr; i++ {
+			carry += uint16(malleated[sizeFr+i]) + uint16(l[i])
+			malleated[sizeFr+i] = byte(carry)
+			carry >>= 8
+		}
+		if _, err := privKey.PublicKey.Verify(malleated, msg, nil); err != errSBiggerThanRMod {
+			t.Fatal("should raise s >= r_mod error")
+		}
+	})
+
+	t.Run("hash_function", func(t *testing.T) {
+		if _, err := privKey.Sign(msg, sha512.New()); err != errHashNotSupported {
+			t.Fatal("should raise hash not supported error")
+		}
+	})
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	signatures := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[i] = privKey.PublicKey
+		messages[i] = []byte{byte(i)}
+		signatures[i], err = privKey.Sign(messages[i], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ok, err := BatchVerify(publicKeys, messages, signatures)
+	if err != nil || !ok {
+		t.Fatal("valid batch rejected")
+	}
+
+	// swap two messages
+	messages[2], messages[5] = messages[5], messages[2]
+	ok, err = BatchVerify(publicKeys, messages, signatures)
+	if err != nil || ok {
+		t.Fatal("invalid batch accepted")
+	}
+
+	if _, err = BatchVerify(publicKeys, messages[1:], signatures); err != errLengthMismatch {
+		t.Fatal("should raise length mismatch error")
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	privKey1, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var privKey2 PrivateKey
+	if _, err := privKey2.SetBytes(privKey1.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(privKey1.Bytes(), privKey2.Bytes()) || !privKey1.Public().Equal(privKey2.Public()) {
+		t.Fatal("private key serialization round trip failed")
+	}
+
+	// tampered public key
+	buf := privKey1.Bytes()
+	buf[sizeSeed] ^= 1
+	if _, err := privKey2.SetBytes(buf); err == nil {
+		t.Fatal("should raise an error on mismatching public key")
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	sig, _ := privKey.Sign(msg, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 64
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	signatures := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, _ := GenerateKey(crand.Reader)
+		publicKeys[i] = privKey.PublicKey
+		messages[i] = []byte{byte(i)}
+		signatures[i], _ = privKey.Sign(messages[i], nil)
+	}
+
+	b.Run("BatchVerify", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			BatchVerify(publicKeys, messages, signatures)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			for i := range publicKeys {
+				publicKeys[i].Verify(signatures[i], messages[i], nil)
+			}
+		}
+	})
+}
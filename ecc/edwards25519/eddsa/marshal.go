@@ -0,0 +1,117 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eddsa
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519/fr"
+)
+
+var errWrongSize = errors.New("wrong size buffer")
+var errSBiggerThanRMod = errors.New("s >= r_mod")
+var errPublicKeyMismatch = errors.New("public key doesn't match the seed")
+
+// Bytes returns the binary representation of the public key
+// follows https://tools.ietf.org/html/rfc8032#section-5.1.2
+// and returns a compressed representation of the point (x,y):
+// y in little endian, with the sign of x in the most significant bit.
+func (pk *PublicKey) Bytes() []byte {
+	var res [sizePublicKey]byte
+	pkBin := pk.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], pkBin[:])
+	return res[:]
+}
+
+// SetBytes sets pk from its compressed binary representation in buf,
+// as in https://tools.ietf.org/html/rfc8032#section-5.1.3.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	return pk.A.SetBytes(buf[:sizePublicKey])
+}
+
+// Bytes returns the binary representation of privKey,
+// as byte array seed||publicKey, the layout of crypto/ed25519.PrivateKey.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizeSeed], privKey.seed[:])
+	subtle.ConstantTimeCopy(1, res[sizeSeed:], pubkBin[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, where buf is interpreted
+// as seed||publicKey, the layout of crypto/ed25519.PrivateKey.
+// The key pair is recomputed from the seed and must match publicKey.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	k, err := NewKeyFromSeed(buf[:sizeSeed])
+	if err != nil {
+		return 0, err
+	}
+	pubkBin := k.PublicKey.A.Bytes()
+	if subtle.ConstantTimeCompare(pubkBin[:], buf[sizeSeed:sizePrivateKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *k
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig
+// as a byte array of size 2*sizeFr R||S where
+//   - R is the compressed point, as in PointAffine.Bytes()
+//   - S is in little endian
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	sigRBin := sig.R.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizeFr], sigRBin[:])
+	subtle.ConstantTimeCopy(1, res[sizeFr:], sig.S[:])
+	return res[:]
+}
+
+// SetBytes sets sig from a buffer in binary.
+// buf is read interpreted as R||S where
+//   - R is the compressed point, as in PointAffine.Bytes()
+//   - S is in little endian, and must be smaller than the order of
+//     the subgroup (to avoid malleability)
+//
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+
+	// S < R_mod (to avoid malleability)
+	var s [sizeFr]byte
+	copy(s[:], buf[sizeFr:])
+	if _, err := fr.LittleEndian.Element(&s); err != nil {
+		return 0, errSBiggerThanRMod
+	}
+
+	// deserialisation
+	if _, err := sig.R.SetBytes(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	sig.S = s
+	return sizeSignature, nil
+}
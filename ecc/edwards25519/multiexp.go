@@ -0,0 +1,99 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edwards25519
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519/fr"
+)
+
+// MultiExp sets p to ∑ [scalars[i]]points[i] and returns it, using the bucket
+// method (Pippenger): for each window of c bits of the scalars, the points are
+// first summed in 2ᶜ-1 buckets, by value of their digit.
+//
+// The scalars are reduced modulo the order ℓ of the prime subgroup; this is
+// exact for points in that subgroup, and up to a point of small order otherwise.
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []fr.Element) (*PointExtended, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// regular (non montgomery) form of the scalars
+	digits := make([][fr.Limbs]uint64, len(scalars))
+	for i := range scalars {
+		digits[i] = scalars[i].Bits()
+	}
+	extended := make([]PointExtended, len(points))
+	for i := range points {
+		extended[i].FromAffine(&points[i])
+	}
+
+	c := multiExpWindow(len(points))
+	nbWindows := (fr.Bits + c - 1) / c
+	buckets := make([]PointExtended, (1<<c)-1)
+
+	var res, sum, acc PointExtended
+	res.setInfinity()
+	for w := nbWindows - 1; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			res.Double(&res)
+		}
+
+		for j := range buckets {
+			buckets[j].setInfinity()
+		}
+		for i := range extended {
+			if d := window(&digits[i], w*c, c); d != 0 {
+				buckets[d-1].Add(&buckets[d-1], &extended[i])
+			}
+		}
+
+		// ∑ⱼ [j]bucketⱼ, as the sum of the running sums from the top bucket
+		sum.setInfinity()
+		acc.setInfinity()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			sum.Add(&sum, &buckets[j])
+			acc.Add(&acc, &sum)
+		}
+		res.Add(&res, &acc)
+	}
+
+	p.Set(&res)
+	return p, nil
+}
+
+// multiExpWindow returns the window size used by MultiExp for n points
+func multiExpWindow(n int) int {
+	c := bits.Len(uint(n)) - 2
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// window returns the c bits of s starting at bit start
+func window(s *[fr.Limbs]uint64, start, c int) uint64 {
+	limb, shift := start/64, uint(start%64)
+	d := s[limb] >> shift
+	if shift+uint(c) > 64 && limb+1 < fr.Limbs {
+		d |= s[limb+1] << (64 - shift)
+	}
+	return d & (1<<c - 1)
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edwards25519
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519/fr"
+)
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	for _, n := range []int{0, 1, 7, 100} {
+		points := make([]PointAffine, n)
+		scalars := make([]fr.Element, n)
+		var expected, tmp PointExtended
+		expected.setInfinity()
+		for i := range points {
+			var s big.Int
+			scalars[i].SetRandom()
+			scalars[i].BigInt(&s)
+			points[i].ScalarMultiplication(&params.Base, &s)
+			switch i {
+			case 1:
+				scalars[i].SetZero()
+			case 2:
+				points[i].setInfinity()
+			case 3:
+				points[i] = points[0] // equal points in the same bucket
+				scalars[i] = scalars[0]
+			case 4:
+				scalars[i].SetOne().Neg(&scalars[i]) // all windows set
+			}
+			scalars[i].BigInt(&s)
+			tmp.FromAffine(&points[i])
+			tmp.ScalarMultiplication(&tmp, &s)
+			expected.Add(&expected, &tmp)
+		}
+
+		var actual PointExtended
+		if _, err := actual.MultiExp(points, scalars); err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			if !actual.IsZero() {
+				t.Fatal("empty MultiExp must be the identity")
+			}
+			continue
+		}
+		if !actual.Equal(&expected) {
+			t.Fatalf("MultiExp of %d points mismatch", n)
+		}
+	}
+
+	var p PointExtended
+	if _, err := p.MultiExp(make([]PointAffine, 2), make([]fr.Element, 1)); err == nil {
+		t.Fatal("should raise an error on length mismatch")
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	params := GetEdwardsCurve()
+	const n = 129
+	points := make([]PointAffine, n)
+	scalars := make([]fr.Element, n)
+	for i := range points {
+		var s big.Int
+		scalars[i].SetRandom()
+		scalars[i].BigInt(&s)
+		points[i].ScalarMultiplication(&params.Base, &s)
+	}
+
+	var p PointExtended
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.MultiExp(points, scalars)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ristretto255
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519"
+	"github.com/consensys/gnark-crypto/ecc/edwards25519/fp"
+)
+
+// ErrInvalidEncoding is returned by SetBytes when the buffer is not the canonical
+// encoding of a ristretto255 element.
+var ErrInvalidEncoding = errors.New("invalid ristretto255 encoding")
+
+var errWrongSize = errors.New("wrong size buffer")
+
+const (
+	// SizeElement size in bytes of an encoded element
+	SizeElement = fp.Bytes
+
+	// SizeUniformBytes size in bytes of the input of SetUniformBytes
+	SizeUniformBytes = 2 * fp.Bytes
+)
+
+// Element of the ristretto255 group
+type Element struct {
+	p edwards25519.PointExtended
+}
+
+// constants of https://www.rfc-editor.org/rfc/rfc9496#section-4.1
+var (
+	sqrtM1, sqrtADMinusOne, invSqrtAMinusD, oneMinusDSq, dMinusOneSq, d fp.Element
+	sqrtRatioExp                                                        big.Int
+)
+
+func init() {
+	sqrtM1.SetString("19681161376707505956807079304988542015446066515923890162744021073123829784752")
+	sqrtADMinusOne.SetString("25063068953384623474111414158702152701244531502492656460079210482610430750235")
+	invSqrtAMinusD.SetString("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	oneMinusDSq.SetString("1159843021668779879193775521855586647937357759715417654439879720876111806838")
+	dMinusOneSq.SetString("40440834346308536858101042469323190826248399146238708352240133220865137265952")
+	d = edwards25519.GetEdwardsCurve().D
+
+	// (p-5)/8
+	sqrtRatioExp.SetUint64(5)
+	sqrtRatioExp.Sub(fp.Modulus(), &sqrtRatioExp).Rsh(&sqrtRatioExp, 3)
+}
+
+// Generator returns the canonical generator of ristretto255, the class of the edwards25519 base point
+func Generator() Element {
+	params := edwards25519.GetEdwardsCurve()
+	var e Element
+	e.p.FromAffine(&params.Base)
+	return e
+}
+
+// SetZero sets e to the identity element and returns it
+func (e *Element) SetZero() *Element {
+	e.p.X.SetZero()
+	e.p.Y.SetOne()
+	e.p.Z.SetOne()
+	e.p.T.SetZero()
+	return e
+}
+
+// Set sets e to e1 and returns it
+func (e *Element) Set(e1 *Element) *Element {
+	e.p.Set(&e1.p)
+	return e
+}
+
+// Point returns an edwards25519 representative of e
+func (e *Element) Point() edwards25519.PointExtended {
+	return e.p
+}
+
+// Equal returns true if e and e1 are the same element,
+// i.e. their representatives differ by a 4-torsion point
+// https://www.rfc-editor.org/rfc/rfc9496#section-4.3.3
+func (e *Element) Equal(e1 *Element) bool {
+	var lhs, rhs fp.Element
+	lhs.Mul(&e.p.X, &e1.p.Y)
+	rhs.Mul(&e.p.Y, &e1.p.X)
+	if lhs.Equal(&rhs) {
+		return true
+	}
+	lhs.Mul(&e.p.Y, &e1.p.Y)
+	rhs.Mul(&e.p.X, &e1.p.X)
+	return lhs.Equal(&rhs)
+}
+
+// IsZero returns true if e is the identity element
+func (e *Element) IsZero() bool {
+	var zero Element
+	zero.SetZero()
+	return e.Equal(&zero)
+}
+
+// Add sets e to e1+e2 and returns it
+func (e *Element) Add(e1, e2 *Element) *Element {
+	e.p.Add(&e1.p, &e2.p)
+	return e
+}
+
+// Sub sets e to e1-e2 and returns it
+func (e *Element) Sub(e1, e2 *Element) *Element {
+	var neg edwards25519.PointExtended
+	neg.Neg(&e2.p)
+	e.p.Add(&e1.p, &neg)
+	return e
+}
+
+// Neg sets e to -e1 and returns it
+func (e *Element) Neg(e1 *Element) *Element {
+	e.p.Neg(&e1.p)
+	return e
+}
+
+// ScalarMultiplication sets e to [scalar]e1 and returns it
+func (e *Element) ScalarMultiplication(e1 *Element, scalar *big.Int) *Element {
+	e.p.ScalarMultiplication(&e1.p, scalar)
+	return e
+}
+
+// Bytes returns the canonical encoding of e
+// https://www.rfc-editor.org/rfc/rfc9496#section-4.3.2
+func (e *Element) Bytes() [SizeElement]byte {
+	var u1, u2, tmp, invSqrt, den1, den2, zInv, x, y, denInv, s fp.Element
+	var one fp.Element
+	one.SetOne()
+
+	// u1 = (z0 + y0) * (z0 - y0), u2 = x0 * y0
+	u1.Add(&e.p.Z, &e.p.Y)
+	tmp.Sub(&e.p.Z, &e.p.Y)
+	u1.Mul(&u1, &tmp)
+	u2.Mul(&e.p.X, &e.p.Y)
+
+	// invsqrt = 1/sqrt(u1 * u2²)
+	tmp.Square(&u2).Mul(&tmp, &u1)
+	sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	den1.Mul(&invSqrt, &u1)
+	den2.Mul(&invSqrt, &u2)
+	zInv.Mul(&den1, &den2).Mul(&zInv, &e.p.T)
+
+	tmp.Mul(&e.p.T, &zInv)
+	if isNegative(&tmp) {
+		// rotate
+		x.Mul(&e.p.Y, &sqrtM1)
+		y.Mul(&e.p.X, &sqrtM1)
+		denInv.Mul(&den1, &invSqrtAMinusD)
+	} else {
+		x.Set(&e.p.X)
+		y.Set(&e.p.Y)
+		denInv.Set(&den2)
+	}
+
+	tmp.Mul(&x, &zInv)
+	if isNegative(&tmp) {
+		y.Neg(&y)
+	}
+
+	s.Sub(&e.p.Z, &y).Mul(&s, &denInv)
+	abs(&s)
+
+	var res [SizeElement]byte
+	fp.LittleEndian.PutElement(&res, s)
+	return res
+}
+
+// Marshal converts e to a byte slice
+func (e *Element) Marshal() []byte {
+	b := e.Bytes()
+	return b[:]
+}
+
+// SetBytes sets e from its canonical encoding in buf[:SizeElement]
+// https://www.rfc-editor.org/rfc/rfc9496#section-4.3.1
+// It returns the number of bytes read, and ErrInvalidEncoding if buf
+// is not a canonical encoding.
+func (e *Element) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeElement {
+		return 0, io.ErrShortBuffer
+	}
+	var bufCopy [SizeElement]byte
+	copy(bufCopy[:], buf[:SizeElement])
+
+	s, err := fp.LittleEndian.Element(&bufCopy)
+	if err != nil || isNegative(&s) {
+		return 0, ErrInvalidEncoding
+	}
+
+	var one, ss, u1, u2, u2Sqr, v, tmp, invSqrt, denX, denY, x, y, t fp.Element
+	one.SetOne()
+	ss.Square(&s)
+	u1.Sub(&one, &ss)
+	u2.Add(&one, &ss)
+	u2Sqr.Square(&u2)
+
+	// v = -(D * u1²) - u2²
+	v.Square(&u1).Mul(&v, &d).Neg(&v).Sub(&v, &u2Sqr)
+
+	tmp.Mul(&v, &u2Sqr)
+	wasSquare := sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	denX.Mul(&invSqrt, &u2)
+	denY.Mul(&invSqrt, &denX).Mul(&denY, &v)
+
+	x.Double(&s).Mul(&x, &denX)
+	abs(&x)
+	y.Mul(&u1, &denY)
+	t.Mul(&x, &y)
+
+	if !wasSquare || isNegative(&t) || y.IsZero() {
+		return 0, ErrInvalidEncoding
+	}
+
+	e.p.X = x
+	e.p.Y = y
+	e.p.Z = one
+	e.p.T = t
+	return SizeElement, nil
+}
+
+// Unmarshal alias to SetBytes()
+func (e *Element) Unmarshal(buf []byte) error {
+	_, err := e.SetBytes(buf)
+	return err
+}
+
+// SetUniformBytes maps 64 uniformly random bytes to an element, as in
+// https://www.rfc-editor.org/rfc/rfc9496#section-4.3.4. It is meant to be used
+// with the output of a hash function, e.g. SHA-512, to hash to the group.
+func (e *Element) SetUniformBytes(buf []byte) (*Element, error) {
+	if len(buf) != SizeUniformBytes {
+		return nil, errWrongSize
+	}
+	var t1, t2 fp.Element
+	setFieldElement(&t1, buf[:fp.Bytes])
+	setFieldElement(&t2, buf[fp.Bytes:])
+
+	var p1, p2 edwards25519.PointExtended
+	elligator(&p1, &t1)
+	elligator(&p2, &t2)
+	e.p.Add(&p1, &p2)
+	return e, nil
+}
+
+// elligator sets p to MAP(t), https://www.rfc-editor.org/rfc/rfc9496#section-4.3.4
+func elligator(p *edwards25519.PointExtended, t *fp.Element) {
+	var one, r, u, v, tmp, s, c, n, w0, w1, w2, w3 fp.Element
+	one.SetOne()
+
+	// r = SQRT_M1 * t²
+	r.Square(t).Mul(&r, &sqrtM1)
+
+	// u = (r + 1) * ONE_MINUS_D_SQ
+	u.Add(&r, &one).Mul(&u, &oneMinusDSq)
+
+	// v = (-1 - r*D) * (r + D)
+	v.Mul(&r, &d).Add(&v, &one).Neg(&v)
+	tmp.Add(&r, &d)
+	v.Mul(&v, &tmp)
+
+	wasSquare := sqrtRatioM1(&s, &u, &v)
+	if wasSquare {
+		c.Neg(&one)
+	} else {
+		// s = -|s*t|
+		s.Mul(&s, t)
+		abs(&s)
+		s.Neg(&s)
+		c.Set(&r)
+	}
+
+	// N = c * (r - 1) * D_MINUS_ONE_SQ - v
+	n.Sub(&r, &one).Mul(&n, &c).Mul(&n, &dMinusOneSq).Sub(&n, &v)
+
+	w0.Double(&s).Mul(&w0, &v)
+	w1.Mul(&n, &sqrtADMinusOne)
+	tmp.Square(&s)
+	w2.Sub(&one, &tmp)
+	w3.Add(&one, &tmp)
+
+	p.X.Mul(&w0, &w3)
+	p.Y.Mul(&w2, &w1)
+	p.Z.Mul(&w1, &w3)
+	p.T.Mul(&w0, &w2)
+}
+
+// sqrtRatioM1 sets r to the non-negative square root of u/v if it exists, or of
+// SQRT_M1*u/v otherwise. It returns true if u/v is a square.
+// https://www.rfc-editor.org/rfc/rfc9496#section-4.2
+func sqrtRatioM1(r, u, v *fp.Element) bool {
+	var v3, v7, check, tmp fp.Element
+
+	// r = (u * v³) * (u * v⁷)^((p-5)/8)
+	v3.Square(v).Mul(&v3, v)
+	v7.Square(&v3).Mul(&v7, v)
+	tmp.Mul(u, &v7)
+	tmp.Exp(tmp, &sqrtRatioExp)
+	r.Mul(u, &v3).Mul(r, &tmp)
+
+	// check = v * r²
+	check.Square(r).Mul(&check, v)
+
+	var uNeg, uNegI fp.Element
+	uNeg.Neg(u)
+	uNegI.Mul(&uNeg, &sqrtM1)
+
+	correctSignSqrt := check.Equal(u)
+	flippedSignSqrt := check.Equal(&uNeg)
+	flippedSignSqrtI := check.Equal(&uNegI)
+
+	if flippedSignSqrt || flippedSignSqrtI {
+		r.Mul(r, &sqrtM1)
+	}
+	abs(r)
+
+	return correctSignSqrt || flippedSignSqrt
+}
+
+// isNegative returns true if the canonical encoding of x is odd
+func isNegative(x *fp.Element) bool {
+	return x.Bits()[0]&1 == 1
+}
+
+// abs sets x to -x if x is negative
+func abs(x *fp.Element) {
+	if isNegative(x) {
+		x.Neg(x)
+	}
+}
+
+// setFieldElement sets z to the little endian integer in buf,
+// with the most significant bit masked, reduced mod p
+func setFieldElement(z *fp.Element, buf []byte) {
+	var be [fp.Bytes]byte
+	for i, j := 0, fp.Bytes-1; i < fp.Bytes; i, j = i+1, j-1 {
+		be[i] = buf[j]
+	}
+	be[0] &= 0x7f
+	z.SetBytes(be[:])
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ristretto255
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/edwards25519"
+)
+
+// https://www.rfc-editor.org/rfc/rfc9496#appendix-A.1
+var multiplesOfGenerator = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+	"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+	"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	"e882b131016b52c1d3337080187cf768423efccbb517bb495ab812c4160ff44e",
+	"f64746d3c92b13050ed8d80236a7f0007c3b3f962f5ba793d19a601ebb1df403",
+	"44f53520926ec81fbd5a387845beb7df85a96a24ece18738bdcfa6a7822a176d",
+	"903293d8f2287ebe10e2374dc1a53e0bc887e592699f02d077d5263cdd55601c",
+	"02622ace8f7303a31cafc63f8fc48fdc16e1c8c8d234b2f0d6685282a9076031",
+	"20706fd788b2720a1ed2a5dad4952b01f413bcf0e7564de8cdc816689e2db95f",
+	"bce83f8ba5dd2fa572864c24ba1810f9522bc6004afe95877ac73241cafdab42",
+	"e4549ee16b9aa03099ca208c67adafcafa4c3f3e4e5303de6026e3ca8ff84460",
+	"aa52e000df2e16f55fb1032fc33bc42742dad6bd5a8fc0be0167436c5948501f",
+	"46376b80f409b29dc2b5f6f0c52591990896e5716f41477cd30085ab7f10301e",
+	"e0c418f7c8d9c4cdd7395b93ea124f3ad99021bb681dfc3302a9d99a2e53e64e",
+}
+
+func TestMultiplesOfGenerator(t *testing.T) {
+	t.Parallel()
+
+	g := Generator()
+	var acc Element
+	acc.SetZero()
+	for i, v := range multiplesOfGenerator {
+		b := acc.Bytes()
+		if hex.EncodeToString(b[:]) != v {
+			t.Fatalf("wrong encoding of [%d]G", i)
+		}
+
+		var dec Element
+		if _, err := dec.SetBytes(b[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !dec.Equal(&acc) {
+			t.Fatalf("decoding of [%d]G failed", i)
+		}
+
+		var sm Element
+		sm.ScalarMultiplication(&g, big.NewInt(int64(i)))
+		if !sm.Equal(&acc) {
+			t.Fatalf("[%d]G mismatch", i)
+		}
+
+		acc.Add(&acc, &g)
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc9496#appendix-A.2
+func TestInvalidEncodings(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		// non-canonical field encodings
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"ed57ffd8c914fb201471d1c3d245ce3c746fcbe63a3679d51b6a516ebebe0e20",
+		// non-square x²
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+		"4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f",
+		// negative xy value
+		"3eb858e78f5a7254d8c9731174a94f76755fd3941c0ac93735c07ba14579630e",
+		// s = -1, which causes y = 0
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	}
+	for _, s := range invalid {
+		buf, _ := hex.DecodeString(s)
+		var e Element
+		if _, err := e.SetBytes(buf); err != ErrInvalidEncoding {
+			t.Fatalf("%s: expected ErrInvalidEncoding, got %v", s, err)
+		}
+	}
+}
+
+func TestTorsionInvariance(t *testing.T) {
+	t.Parallel()
+
+	// representatives that differ by a 4-torsion point encode to the same bytes
+	var e, e1 Element
+	g := Generator()
+	e.ScalarMultiplication(&g, big.NewInt(42))
+
+	var t2 edwards25519.PointExtended
+	t2.X.SetZero()
+	t2.Y.SetOne()
+	t2.Y.Neg(&t2.Y)
+	t2.Z.SetOne()
+	t2.T.SetZero()
+	e1.p.Add(&e.p, &t2)
+
+	if !e.Equal(&e1) || e.Bytes() != e1.Bytes() {
+		t.Fatal("e and e + (0, -1) should be the same element")
+	}
+
+	var sum Element
+	sum.Sub(&e, &e1)
+	if !sum.IsZero() {
+		t.Fatal("e - e should be zero")
+	}
+}
+
+func TestSetUniformBytes(t *testing.T) {
+	t.Parallel()
+
+	params := edwards25519.GetEdwardsCurve()
+	for i := 0; i < 16; i++ {
+		h := sha512.Sum512([]byte{byte(i)})
+
+		var e Element
+		if _, err := e.SetUniformBytes(h[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		// the representative is on the curve
+		var a edwards25519.PointAffine
+		a.FromExtended(&e.p)
+		if !a.IsOnCurve() {
+			t.Fatal("SetUniformBytes output is not on the curve")
+		}
+		var q edwards25519.PointExtended
+		q.ScalarMultiplication(&e.p, &params.Order)
+		var order Element
+		order.p = q
+		if !order.IsZero() {
+			t.Fatal("SetUniformBytes output is not in the prime order group")
+		}
+
+		// and round trips
+		b := e.Bytes()
+		var dec Element
+		if _, err := dec.SetBytes(b[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !dec.Equal(&e) {
+			t.Fatal("decode(encode(SetUniformBytes)) failed")
+		}
+	}
+
+	var e Element
+	if _, err := e.SetUniformBytes(make([]byte, 32)); err != errWrongSize {
+		t.Fatal("should raise wrong size error")
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	g := Generator()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Bytes()
+	}
+}
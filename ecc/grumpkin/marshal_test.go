@@ -0,0 +1,227 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grumpkin
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fp"
+)
+
+func TestG1AffineSerialization(t *testing.T) {
+	t.Parallel()
+	// test round trip serialization of infinity
+	{
+		// compressed
+		{
+			var p1, p2 G1Affine
+			p2.X.SetRandom()
+			p2.Y.SetRandom()
+			buf := p1.Bytes()
+			if buf[0] != mCompressedInfinity {
+				t.Fatal("compressed infinity point must be flagged")
+			}
+			n, err := p2.SetBytes(buf[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != SizeOfG1AffineCompressed {
+				t.Fatal("invalid number of bytes consumed in buffer")
+			}
+			if !(p2.X.IsZero() && p2.Y.IsZero()) {
+				t.Fatal("deserialization of compressed infinity point is not infinity")
+			}
+			buf[0] |= 1
+			if _, err := p2.SetBytes(buf[:]); err != ErrInvalidInfinityEncoding {
+				t.Fatal("expected invalid infinity encoding error")
+			}
+			buf[0] = mCompressedInfinity
+			buf[SizeOfG1AffineCompressed-1] = 1
+			if _, err := p2.SetBytes(buf[:]); err != ErrInvalidInfinityEncoding {
+				t.Fatal("expected invalid infinity encoding error")
+			}
+		}
+
+		// uncompressed
+		{
+			var p1, p2 G1Affine
+			p2.X.SetRandom()
+			p2.Y.SetRandom()
+			buf := p1.RawBytes()
+			n, err := p2.SetBytes(buf[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != SizeOfG1AffineUncompressed {
+				t.Fatal("invalid number of bytes consumed in buffer")
+			}
+			if !(p2.X.IsZero() && p2.Y.IsZero()) {
+				t.Fatal("deserialization of uncompressed infinity point is not infinity")
+			}
+		}
+	}
+
+	// invalid encodings
+	{
+		var p G1Affine
+		buf := g1GenAff.RawBytes()
+		compressed := g1GenAff.Bytes()
+
+		// short buffers
+		if _, err := p.SetBytes(compressed[:SizeOfG1AffineCompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("expected short buffer error")
+		}
+		if _, err := p.SetBytes(buf[:SizeOfG1AffineCompressed]); err != io.ErrShortBuffer {
+			t.Fatal("expected short buffer error for an uncompressed point")
+		}
+
+		// the uncompressed flag on a compressed point
+		invalid := compressed
+		invalid[0] &^= mMask
+		if _, err := p.SetBytes(invalid[:]); err == nil {
+			t.Fatal("compressed point read as uncompressed")
+		}
+
+		// non canonical coordinates
+		var q big.Int
+		q.Add(fp.Modulus(), g1GenAff.X.BigInt(new(big.Int)))
+		if q.BitLen() <= fp.Bits {
+			invalid := buf
+			q.FillBytes(invalid[:fp.Bytes])
+			if _, err := p.SetBytes(invalid[:]); err == nil {
+				t.Fatal("non canonical X accepted")
+			}
+		}
+		invalid = compressed
+		fp.Modulus().FillBytes(invalid[:fp.Bytes])
+		invalid[0] |= mCompressedSmallest
+		if _, err := p.SetBytes(invalid[:]); err == nil {
+			t.Fatal("non canonical compressed X accepted")
+		}
+
+		// points that are not on the curve
+		invalidRaw := buf
+		invalidRaw[SizeOfG1AffineUncompressed-1] ^= 1
+		if _, err := p.SetBytes(invalidRaw[:]); err == nil {
+			t.Fatal("point not on the curve accepted")
+		}
+		var x, y2 fp.Element
+		for {
+			x.SetRandom()
+			y2.Square(&x).Mul(&y2, &x).Add(&y2, &bCurveCoeff)
+			if y2.Legendre() == -1 {
+				break
+			}
+		}
+		fp.BigEndian.PutElement((*[fp.Bytes]byte)(invalid[:fp.Bytes]), x)
+		invalid[0] |= mCompressedLargest
+		if _, err := p.SetBytes(invalid[:]); err == nil {
+			t.Fatal("compressed X with no square root accepted")
+		}
+	}
+
+	// both compression flags
+	{
+		var p, neg G1Affine
+		neg.Neg(&g1GenAff)
+		for _, start := range []*G1Affine{&g1GenAff, &neg} {
+			buf := start.Bytes()
+			expectedFlag := mCompressedSmallest
+			if start.Y.LexicographicallyLargest() {
+				expectedFlag = mCompressedLargest
+			}
+			if buf[0]&mMask != expectedFlag {
+				t.Fatal("wrong compression flag")
+			}
+			if _, err := p.SetBytes(buf[:]); err != nil {
+				t.Fatal(err)
+			}
+			if !p.Equal(start) {
+				t.Fatal("decompression failed")
+			}
+		}
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[G1] Affine SetBytes(RawBytes) should stay the same", prop.ForAll(
+		func(a fp.Element) bool {
+			var start, end G1Affine
+			var ab big.Int
+			a.BigInt(&ab)
+			start.ScalarMultiplication(&g1GenAff, &ab)
+
+			buf := start.RawBytes()
+			n, err := end.SetBytes(buf[:])
+			if err != nil {
+				return false
+			}
+			if n != SizeOfG1AffineUncompressed {
+				return false
+			}
+			return start.X.Equal(&end.X) && start.Y.Equal(&end.Y)
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] Affine SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func(a fp.Element) bool {
+			var start, end G1Affine
+			var ab big.Int
+			a.BigInt(&ab)
+			start.ScalarMultiplication(&g1GenAff, &ab)
+
+			buf := start.Bytes()
+			n, err := end.SetBytes(buf[:])
+			if err != nil {
+				return false
+			}
+			if n != SizeOfG1AffineCompressed {
+				return false
+			}
+			return start.X.Equal(&end.X) && start.Y.Equal(&end.Y)
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] Affine Unmarshal(Marshal()) should stay the same", prop.ForAll(
+		func(a fp.Element) bool {
+			var start, end G1Affine
+			var ab big.Int
+			a.BigInt(&ab)
+			start.ScalarMultiplication(&g1GenAff, &ab)
+
+			if err := end.Unmarshal(start.Marshal()); err != nil {
+				return false
+			}
+			return start.Equal(&end)
+		},
+		GenFp(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
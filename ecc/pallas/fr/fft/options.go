@@ -0,0 +1,155 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc/pallas/fr"
+)
+
+// Option defines option for altering the behavior of FFT methods.
+// See the descriptions of functions returning instances of this type for
+// particular options.
+type Option func(*fftConfig)
+
+type fftConfig struct {
+	coset     bool
+	nbTasks   int
+	chunkSize int
+	fourStep  bool
+}
+
+// OnCoset if provided, FFT(a) returns the evaluation of a on a coset.
+func OnCoset() Option {
+	return func(opt *fftConfig) {
+		opt.coset = true
+	}
+}
+
+// WithNbTasks sets the max number of task (go routine) to spawn. Must be between 1 and 512.
+func WithNbTasks(nbTasks int) Option {
+	if nbTasks < 1 {
+		nbTasks = 1
+	} else if nbTasks > 512 {
+		nbTasks = 512
+	}
+	return func(opt *fftConfig) {
+		opt.nbTasks = nbTasks
+	}
+}
+
+// WithFourStep selects the four-step (Bailey) FFT: the transform is split in FFTs of size ~√n on
+// contiguous rows, separated by cache-blocked transpositions, with the bit reversal and coset scaling fused
+// into the passes. It uses n extra elements of memory; whether it beats the recursive kernels depends
+// on the cache hierarchy, see BenchmarkFFTFourStep. It only applies to power of 2 domains.
+func WithFourStep() Option {
+	return func(opt *fftConfig) {
+		opt.fourStep = true
+	}
+}
+
+// WithChunkSize sets the number of elements held in memory by the out-of-core FFTs
+// (FFTReaderAt, FFTInverseReaderAt). Default is 1 << 22.
+func WithChunkSize(nbElements int) Option {
+	if nbElements < 1 {
+		nbElements = 1
+	}
+	return func(opt *fftConfig) {
+		opt.chunkSize = nbElements
+	}
+}
+
+// default options
+func fftOptions(opts ...Option) fftConfig {
+	// apply options
+	opt := fftConfig{
+		coset:     false,
+		nbTasks:   runtime.NumCPU(),
+		chunkSize: 1 << 22,
+	}
+	for _, option := range opts {
+		option(&opt)
+	}
+	return opt
+}
+
+// DomainOption defines option for altering the definition of the FFT domain
+// See the descriptions of functions returning instances of this type for
+// particular options.
+type DomainOption func(*domainConfig)
+
+type domainConfig struct {
+	shift          *fr.Element
+	withPrecompute bool
+	cardinality    cardinalityPolicy
+}
+
+// cardinalityPolicy defines how the cardinality of a domain is derived from the requested size
+type cardinalityPolicy uint8
+
+const (
+	powerOfTwoCardinality cardinalityPolicy = iota
+	smoothCardinality
+	exactCardinality
+)
+
+// WithShift sets the FrMultiplicativeGen of the domain.
+// Default is generator of the largest 2-adic subgroup.
+func WithShift(shift fr.Element) DomainOption {
+	return func(opt *domainConfig) {
+		opt.shift = new(fr.Element).Set(&shift)
+	}
+}
+
+// WithoutPrecompute disables precomputation of twiddles in the domain.
+// When this option is set, FFTs will be slower, but will use less memory.
+func WithoutPrecompute() DomainOption {
+	return func(opt *domainConfig) {
+		opt.withPrecompute = false
+	}
+}
+
+// WithSmoothCardinality sets the cardinality of the domain to the smallest n ⩾ m
+// such that n divides r-1 and has only small prime factors (2, 3, ...).
+// For instance, m = 3·2ᵏ is not rounded up to 2ᵏ⁺².
+// FFTs on such domains use a mixed radix algorithm; inputs and outputs are in natural order.
+func WithSmoothCardinality() DomainOption {
+	return func(opt *domainConfig) {
+		opt.cardinality = smoothCardinality
+	}
+}
+
+// WithExactCardinality sets the cardinality of the domain to m, which must divide r-1.
+// If m has a large prime factor, FFTs use Bluestein's algorithm; inputs and outputs are in natural order.
+func WithExactCardinality() DomainOption {
+	return func(opt *domainConfig) {
+		opt.cardinality = exactCardinality
+	}
+}
+
+// default options
+func domainOptions(opts ...DomainOption) domainConfig {
+	// apply options
+	opt := domainConfig{
+		withPrecompute: true,
+	}
+	for _, option := range opts {
+		option(&opt)
+	}
+	return opt
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+)
+
+// Duplex is a stateful transcript. Unlike Transcript, challenges need not be declared
+// in advance: labeled messages are absorbed and challenges squeezed in any order,
+// each challenge depending on everything absorbed or squeezed before it.
+type Duplex interface {
+	// Absorb adds a labeled message to the transcript
+	Absorb(label string, msg []byte) error
+	// Squeeze returns n bytes of challenge
+	Squeeze(label string, n int) ([]byte, error)
+}
+
+// operation codes, absorbed with each label for domain separation
+const (
+	opDomain byte = iota
+	opAbsorb
+	opSqueeze
+	opAbsorbElements
+	opSqueezeElements
+)
+
+var (
+	errLabelTooLong   = errors.New("label too long")
+	errNotAFieldHash  = errors.New("the hash function must have a block size and a digest size equal to the size of a field element")
+	errInvalidElement = errors.New("invalid element encoding: must be a list of canonical field elements")
+	errInvalidLength  = errors.New("invalid challenge length: must be between 0 and 2³²-1")
+)
+
+// checkLength returns an error if n can't be absorbed as a big endian uint32
+func checkLength(n int) error {
+	if n < 0 || uint64(n) > math.MaxUint32 {
+		return errInvalidLength
+	}
+	return nil
+}
+
+// HashDuplex is a Duplex built on a byte oriented hash function, following Merlin's framing
+// (https://merlin.cool): every operation is absorbed as
+//
+//	op ‖ len(label) ‖ label ‖ len(msg) ‖ msg
+//
+// with lengths as big endian uint32, so that no two sequences of operations are absorbed the same way.
+// Squeezing n bytes outputs H(s ‖ 0) ‖ H(s ‖ 1) ‖ ..., where s is the current digest,
+// and restarts the hash from H(s ‖ "ratchet").
+type HashDuplex struct {
+	h hash.Hash
+}
+
+// NewHashDuplex returns a HashDuplex using h, separated from other protocols by domain.
+func NewHashDuplex(h hash.Hash, domain string) *HashDuplex {
+	h.Reset()
+	d := &HashDuplex{h: h}
+	d.frame(opDomain, domain, nil)
+	return d
+}
+
+func (d *HashDuplex) frame(op byte, label string, msg []byte) {
+	var buf [4]byte
+	d.h.Write([]byte{op})
+	binary.BigEndian.PutUint32(buf[:], uint32(len(label)))
+	d.h.Write(buf[:])
+	d.h.Write([]byte(label))
+	binary.BigEndian.PutUint32(buf[:], uint32(len(msg)))
+	d.h.Write(buf[:])
+	d.h.Write(msg)
+}
+
+// Absorb implements Duplex
+func (d *HashDuplex) Absorb(label string, msg []byte) error {
+	d.frame(opAbsorb, label, msg)
+	return nil
+}
+
+// Squeeze implements Duplex
+func (d *HashDuplex) Squeeze(label string, n int) ([]byte, error) {
+	if err := checkLength(n); err != nil {
+		return nil, err
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(n))
+	d.frame(opSqueeze, label, buf[:])
+	seed := d.h.Sum(nil)
+
+	res := make([]byte, 0, n+d.h.Size())
+	for i := uint32(0); len(res) < n; i++ {
+		d.h.Reset()
+		d.h.Write(seed)
+		binary.BigEndian.PutUint32(buf[:], i)
+		d.h.Write(buf[:])
+		res = d.h.Sum(res)
+	}
+
+	d.h.Reset()
+	d.h.Write(seed)
+	d.h.Write([]byte("ratchet"))
+	ratchet := d.h.Sum(nil)
+	d.h.Reset()
+	d.h.Write(ratchet)
+
+	return res[:n], nil
+}
+
+// Sponge is a Duplex built on an algebraic hash function h, such as MiMC, whose Write
+// takes canonical field elements of h.BlockSize() bytes and whose Sum returns the current state
+// without resetting it. It is meant to be cheap to recompute in a SNARK circuit.
+//
+// The state s is a single field element, and absorbing x sets s ← f(s, x), where f is the
+// compression function of h. Following SAFE (https://eprint.iacr.org/2023/522), each operation is
+// preceded by a single domain separation element encoding
+//
+//	op ‖ len(label) ‖ label
+//
+// padded on the left, which in circuit is a constant. Raw messages are prefixed by their length,
+// and packed in chunks of h.BlockSize()-1 bytes so as to always be canonical; field elements absorbed
+// with AbsorbElements cost one compression each. Squeezing returns the state, then absorbs it to
+// produce the next output. The number of bytes squeezed with Squeeze is absorbed beforehand.
+type Sponge struct {
+	h         hash.Hash
+	blockSize int
+}
+
+// NewSponge returns a Sponge using h, separated from other protocols by domain.
+func NewSponge(h hash.Hash, domain string) (*Sponge, error) {
+	if h.BlockSize() != h.Size() || h.BlockSize() < 8 {
+		return nil, errNotAFieldHash
+	}
+	h.Reset()
+	s := &Sponge{h: h, blockSize: h.BlockSize()}
+	if err := s.frame(opDomain, domain); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MaxLabelLen returns the length of the longest label accepted by the sponge
+func (s *Sponge) MaxLabelLen() int {
+	return s.blockSize - 6
+}
+
+// frame absorbs the domain separation element of an operation
+func (s *Sponge) frame(op byte, label string) error {
+	if len(label) > s.MaxLabelLen() {
+		return fmt.Errorf("%w: \"%s\" is longer than %d bytes", errLabelTooLong, label, s.MaxLabelLen())
+	}
+	block := make([]byte, s.blockSize)
+	offset := s.blockSize - len(label)
+	copy(block[offset:], label)
+	binary.BigEndian.PutUint32(block[offset-4:], uint32(len(label)))
+	block[offset-5] = op
+	_, err := s.h.Write(block)
+	return err
+}
+
+// writeLength absorbs n as a field element
+func (s *Sponge) writeLength(n int) error {
+	block := make([]byte, s.blockSize)
+	binary.BigEndian.PutUint64(block[s.blockSize-8:], uint64(n))
+	_, err := s.h.Write(block)
+	return err
+}
+
+// Absorb implements Duplex
+func (s *Sponge) Absorb(label string, msg []byte) error {
+	if err := s.frame(opAbsorb, label); err != nil {
+		return err
+	}
+	if err := s.writeLength(len(msg)); err != nil {
+		return err
+	}
+	chunkSize := s.blockSize - 1
+	block := make([]byte, s.blockSize)
+	for len(msg) > 0 {
+		n := chunkSize
+		if n > len(msg) {
+			n = len(msg)
+		}
+		for i := range block {
+			block[i] = 0
+		}
+		copy(block[s.blockSize-n:], msg[:n])
+		if _, err := s.h.Write(block); err != nil {
+			return err
+		}
+		msg = msg[n:]
+	}
+	return nil
+}
+
+// AbsorbElements adds labeled field elements, given as the concatenation of their
+// canonical encodings, as expected by the Write method of the hash function.
+func (s *Sponge) AbsorbElements(label string, elements []byte) error {
+	if len(elements)%s.blockSize != 0 {
+		return errInvalidElement
+	}
+	if err := s.frame(opAbsorbElements, label); err != nil {
+		return err
+	}
+	if _, err := s.h.Write(elements); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidElement, err)
+	}
+	return nil
+}
+
+// SqueezeElements returns n field elements of challenge, as the concatenation of
+// their encodings by the Sum method of the hash function.
+func (s *Sponge) SqueezeElements(label string, n int) ([]byte, error) {
+	if err := checkLength(n); err != nil {
+		return nil, err
+	}
+	if err := s.frame(opSqueezeElements, label); err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, n*s.blockSize)
+	for i := 0; i < n; i++ {
+		if i != 0 {
+			if _, err := s.h.Write(res[len(res)-s.blockSize:]); err != nil {
+				return nil, err
+			}
+		}
+		res = s.h.Sum(res)
+	}
+	return res, nil
+}
+
+// Squeeze implements Duplex. Each squeezed element yields its h.BlockSize()-1 least significant bytes.
+func (s *Sponge) Squeeze(label string, n int) ([]byte, error) {
+	if err := checkLength(n); err != nil {
+		return nil, err
+	}
+	if err := s.frame(opSqueeze, label); err != nil {
+		return nil, err
+	}
+	if err := s.writeLength(n); err != nil {
+		return nil, err
+	}
+	chunkSize := s.blockSize - 1
+	res := make([]byte, 0, n+chunkSize)
+	for i := 0; len(res) < n; i++ {
+		if i != 0 {
+			if _, err := s.h.Write(s.h.Sum(nil)); err != nil {
+				return nil, err
+			}
+		}
+		e := s.h.Sum(nil)
+		res = append(res, e[1:]...)
+	}
+	return res[:n], nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+func TestHashDuplex(t *testing.T) {
+	t.Parallel()
+
+	squeeze := func(domain string, messages ...string) []byte {
+		d := NewHashDuplex(sha256.New(), domain)
+		for i := 0; i < len(messages); i += 2 {
+			if err := d.Absorb(messages[i], []byte(messages[i+1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c, err := d.Squeeze("c", 70)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c) != 70 {
+			t.Fatal("wrong challenge length")
+		}
+		return c
+	}
+
+	c := squeeze("test", "a", "v1", "b", "v2")
+	if !bytes.Equal(c, squeeze("test", "a", "v1", "b", "v2")) {
+		t.Fatal("duplex must be deterministic")
+	}
+	for _, other := range [][]byte{
+		squeeze("other", "a", "v1", "b", "v2"),
+		squeeze("test", "b", "v1", "a", "v2"),
+		squeeze("test", "a", "v1b", "", "v2"),
+		squeeze("test", "a", "v1"),
+	} {
+		if bytes.Equal(c, other) {
+			t.Fatal("different transcripts must yield different challenges")
+		}
+	}
+
+	// challenges depend on the previous ones
+	d := NewHashDuplex(sha256.New(), "test")
+	c1, _ := d.Squeeze("c", 32)
+	c2, _ := d.Squeeze("c", 32)
+	if bytes.Equal(c1, c2) {
+		t.Fatal("successive challenges must differ")
+	}
+}
+
+func TestSponge(t *testing.T) {
+	t.Parallel()
+
+	newSponge := func(domain string) *Sponge {
+		s, err := NewSponge(mimc.NewMiMC(), domain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	element := func(v uint64) []byte {
+		var e fr.Element
+		e.SetUint64(v)
+		b := e.Bytes()
+		return b[:]
+	}
+	squeeze := func(domain string, messages ...string) []byte {
+		s := newSponge(domain)
+		for i := 0; i < len(messages); i += 2 {
+			if err := s.Absorb(messages[i], []byte(messages[i+1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c, err := s.Squeeze("c", 70)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c) != 70 {
+			t.Fatal("wrong challenge length")
+		}
+		return c
+	}
+
+	c := squeeze("test", "a", "v1", "b", "v2")
+	if !bytes.Equal(c, squeeze("test", "a", "v1", "b", "v2")) {
+		t.Fatal("sponge must be deterministic")
+	}
+	for _, other := range [][]byte{
+		squeeze("other", "a", "v1", "b", "v2"),
+		squeeze("test", "b", "v1", "a", "v2"),
+		squeeze("test", "a", "v1b", "", "v2"),
+		squeeze("test", "a", "v1"),
+	} {
+		if bytes.Equal(c, other) {
+			t.Fatal("different transcripts must yield different challenges")
+		}
+	}
+
+	// field elements
+	s := newSponge("test")
+	if err := s.AbsorbElements("e", append(element(1), element(2)...)); err != nil {
+		t.Fatal(err)
+	}
+	elements, err := s.SqueezeElements("c", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elements) != 3*fr.Bytes || bytes.Equal(elements[:fr.Bytes], elements[fr.Bytes:2*fr.Bytes]) {
+		t.Fatal("wrong squeezed elements")
+	}
+	c1, _ := s.Squeeze("c", 32)
+	c2, _ := s.Squeeze("c", 32)
+	if bytes.Equal(c1, c2) {
+		t.Fatal("successive challenges must differ")
+	}
+	if err = s.AbsorbElements("e", bytes.Repeat([]byte{0xff}, fr.Bytes)); err == nil {
+		t.Fatal("non canonical element accepted")
+	}
+	if err = s.AbsorbElements("e", element(1)[1:]); err == nil {
+		t.Fatal("truncated element accepted")
+	}
+	if err = s.Absorb(strings.Repeat("l", s.MaxLabelLen()+1), nil); err == nil {
+		t.Fatal("label too long accepted")
+	}
+
+	if _, err = NewSponge(sha256.New(), "test"); err == nil {
+		t.Fatal("sponge accepted a hash whose block size differs from its digest size")
+	}
+}
+
+func TestSqueezeLength(t *testing.T) {
+	t.Parallel()
+
+	sponge, err := NewSponge(mimc.NewMiMC(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	squeezers := []func(n int) ([]byte, error){
+		func(n int) ([]byte, error) { return NewHashDuplex(sha256.New(), "test").Squeeze("c", n) },
+		func(n int) ([]byte, error) { return sponge.Squeeze("c", n) },
+		func(n int) ([]byte, error) { return sponge.SqueezeElements("c", n) },
+	}
+	tooLong := uint64(math.MaxUint32) + 1
+	for _, squeeze := range squeezers {
+		if _, err := squeeze(-1); err == nil {
+			t.Fatal("negative length accepted")
+		}
+		if strconv.IntSize == 64 {
+			if _, err := squeeze(int(tooLong)); err == nil {
+				t.Fatal("length overflowing a uint32 accepted")
+			}
+		}
+		if c, err := squeeze(0); err != nil || len(c) != 0 {
+			t.Fatal("empty challenge rejected")
+		}
+	}
+}
@@ -8,10 +8,10 @@ import (
 	"io"
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
 	"strconv"
 	"errors"
 	"reflect"
-	"strings"
 
 	"github.com/consensys/gnark-crypto/field/hash"
 	"github.com/consensys/gnark-crypto/field/pool"
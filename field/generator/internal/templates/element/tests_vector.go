@@ -9,6 +9,8 @@ import (
 	"sort"
 	"reflect"
 	"bytes"
+	"os"
+	"path/filepath"
 )
 
 
@@ -71,6 +73,39 @@ func TestVectorEmptyRoundTrip(t *testing.T) {
 
 
 
+func TestVectorReadWriteElementsAt(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 2000)
+	for i := range v1 {
+		v1[i].SetRandom()
+	}
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	// read a range
+	v2 := make(Vector, 1500)
+	assert.NoError(v2.ReadElementsAt(bytes.NewReader(b), 300))
+	assert.True(reflect.DeepEqual(v1[300:1800], v2))
+
+	// out of range
+	assert.Error(v2.ReadElementsAt(bytes.NewReader(b), 600))
+
+	// write ranges of a vector in a different order
+	f, err := os.Create(filepath.Join(t.TempDir(), "vector"))
+	assert.NoError(err)
+	defer f.Close()
+	_, err = f.WriteAt(b[:4], 0)
+	assert.NoError(err)
+	assert.NoError(v1[1100:].WriteElementsAt(f, 1100))
+	assert.NoError(v1[:1100].WriteElementsAt(f, 0))
+
+	var v3 Vector
+	_, err = v3.ReadFrom(f)
+	assert.NoError(err)
+	assert.True(reflect.DeepEqual(v1, v3))
+}
+
 func (vector *Vector) unmarshalBinaryAsync(data []byte) error {
 	r := bytes.NewReader(data)
 	_, err, chErr := vector.AsyncReadFrom(r)
@@ -99,4 +99,5 @@ type FieldDependency struct {
 	FieldPackagePath string
 	ElementType      string
 	FieldPackageName string
+	FFTPackagePath   string // empty if the field has no fft package
 }
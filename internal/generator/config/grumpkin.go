@@ -0,0 +1,28 @@
+package config
+
+var GRUMPKIN = Curve{
+	Name:         "grumpkin",
+	CurvePackage: "grumpkin",
+	EnumID:       "GRUMPKIN",
+	FrModulus:    "21888242871839275222246405745257275088696311157297823662689037894645226208583",
+	FpModulus:    "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+	G1: Point{
+		CoordType:        "fp.Element",
+		CoordExtDegree:   1,
+		PointName:        "g1",
+		GLV:              true,
+		CofactorCleaning: false,
+		CRange:           defaultCRange(),
+	},
+	HashE1: &HashSuiteSvdw{
+		z:  []string{"1"},
+		c1: []string{"21888242871839275222246405745257275088548364400416034343698204186575808495601"},
+		c2: []string{"10944121435919637611123202872628637544274182200208017171849102093287904247808"},
+		c3: []string{"17631683881184975371348829942606096167675058198229016842588"},
+		c4: []string{"14592161914559516814830937163504850059032242933610689562465469457717205663766"},
+	},
+}
+
+func init() {
+	addCurve(&GRUMPKIN)
+}
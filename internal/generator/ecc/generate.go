@@ -2,6 +2,7 @@ package ecc
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -33,6 +34,9 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 
 		funcs := make(template.FuncMap)
 		funcs["asElement"] = hashConf.Field.Base.WriteElement
+		funcs["abs"] = func(z big.Int) big.Int {
+			return *new(big.Int).Abs(&z)
+		}
 		bavardOpts := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
 
 		return bgen.GenerateWithOptions(hashConf, packageName, "./ecc/template", bavardOpts, entries...)
@@ -173,8 +177,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		return err
 	}
 
-	// No G2 for secp256k1
-	if conf.Equal(config.SECP256K1) {
+	// No G2 for secp256k1, secp256r1, grumpkin, pallas and vesta
+	if conf.Equal(config.SECP256K1) || conf.Equal(config.SECP256R1) || conf.Equal(config.GRUMPKIN) || conf.Equal(config.PALLAS) || conf.Equal(config.VESTA) {
 		return nil
 	}
 
@@ -182,6 +186,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries = []bavard.Entry{
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_reader.go"), Templates: []string{"multiexp_reader.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_reader_test.go"), Templates: []string{"tests/multiexp_reader.go.tmpl"}},
 	}
 
 	marshal := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
@@ -1,6 +1,8 @@
 package fft
 
 import (
+	"errors"
+	"math/big"
 	"math/bits"
 	"path/filepath"
 
@@ -21,8 +23,22 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "fft.go"), Templates: []string{"fft.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "bitreverse.go"), Templates: []string{"bitreverse.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "options.go"), Templates: []string{"options.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_mixed.go"), Templates: []string{"fft_mixed.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_mixed_test.go"), Templates: []string{"tests/fft_mixed.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_fourstep.go"), Templates: []string{"fft_fourstep.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_fourstep_test.go"), Templates: []string{"tests/fft_fourstep.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_reader.go"), Templates: []string{"fft_reader.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_reader_test.go"), Templates: []string{"tests/fft_reader.go.tmpl", "imports.go.tmpl"}},
 	}
 
+	// factor r-1 by trial division; the small prime factors drive the mixed radix FFT,
+	// the first larger one is used to exercise Bluestein's algorithm in the tests.
+	frModulus, ok := new(big.Int).SetString(conf.FrModulus, 10)
+	if !ok {
+		return errors.New("invalid fr modulus")
+	}
+	smallFactors, largeFactor := factorOrder(frModulus, maxRadix)
+
 	funcs := make(map[string]interface{})
 	funcs["bitReverse"] = func(n, i int64) uint64 {
 		nn := uint64(64 - bits.TrailingZeros64(uint64(n)))
@@ -48,6 +64,13 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		return anyToUint64(x) | anyToUint64(y)
 	}
 
+	funcs["smallFactors"] = func() [][2]uint64 {
+		return smallFactors
+	}
+	funcs["largeFactor"] = func() uint64 {
+		return largeFactor
+	}
+
 	bavardOpts := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
 
 	if err := bgen.GenerateWithOptions(conf, conf.Package, "./fft/template/", bavardOpts, entries...); err != nil {
@@ -74,3 +97,35 @@ func anyToUint64(x any) uint64 {
 		panic("unknown type")
 	}
 }
+
+// maxRadix must match the constant of the same name in fft_mixed.go.tmpl
+const maxRadix = 64
+
+// factorOrder returns the prime factors p <= maxRadix of r-1 with their multiplicity,
+// and the smallest prime factor of r-1 strictly larger than maxRadix (found by trial division).
+func factorOrder(r *big.Int, maxRadix uint64) ([][2]uint64, uint64) {
+	var n, q, m big.Int
+	n.Sub(r, big.NewInt(1))
+	var small [][2]uint64
+	var large uint64
+	for p := uint64(2); p < 1<<20 && large == 0; p++ {
+		e := uint64(0)
+		for {
+			q.DivMod(&n, new(big.Int).SetUint64(p), &m)
+			if m.Sign() != 0 {
+				break
+			}
+			n.Set(&q)
+			e++
+		}
+		if e == 0 {
+			continue
+		}
+		if p <= maxRadix {
+			small = append(small, [2]uint64{p, e})
+		} else {
+			large = p
+		}
+	}
+	return small, large
+}
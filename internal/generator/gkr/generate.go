@@ -17,12 +17,30 @@ type Config struct {
 func Generate(config Config, baseDir string, bgen *bavard.BatchGenerator) error {
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "gkr.go"), Templates: []string{"gkr.go.tmpl"}},
+		{File: filepath.Join(baseDir, "registry.go"), Templates: []string{"registry.go.tmpl"}},
+	}
+
+	// the binary encoding relies on fr.Vector, which the small_rational test field doesn't have
+	if config.ElementType == "fr.Element" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "proof.go"), Templates: []string{"proof.go.tmpl"}})
 	}
 
 	if config.GenerateTests {
 		entries = append(entries,
-			bavard.Entry{File: filepath.Join(baseDir, "gkr_test.go"), Templates: []string{"gkr.test.go.tmpl", "gkr.test.vectors.go.tmpl"}})
+			bavard.Entry{File: filepath.Join(baseDir, "gkr_test.go"), Templates: []string{"gkr.test.go.tmpl", "gkr.test.vectors.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "registry_test.go"), Templates: []string{"registry.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "proof_test.go"), Templates: []string{"proof.test.go.tmpl"}})
 	}
 
 	return bgen.Generate(config, "gkr", "./gkr/template/", entries...)
 }
+
+// GenerateLayered generates the GKR protocol for layered circuits with arbitrary wiring
+func GenerateLayered(conf config.FieldDependency, baseDir string, bgen *bavard.BatchGenerator) error {
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "layered.go"), Templates: []string{"layered.go.tmpl"}},
+		{File: filepath.Join(baseDir, "layered_test.go"), Templates: []string{"layered.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, "layered", "./gkr/template/layered/", entries...)
+}
@@ -18,6 +18,30 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "utils.go"), Templates: []string{"utils.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./kzg/template/", entries...)
+	if err := bgen.Generate(conf, conf.Package, "./kzg/template/", entries...); err != nil {
+		return err
+	}
+
+	// vector commitment with updatable openings
+	conf.Package = "vector"
+	vectorDir := filepath.Join(baseDir, "vector")
+	entries = []bavard.Entry{
+		{File: filepath.Join(vectorDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(vectorDir, "vector.go"), Templates: []string{"vector.go.tmpl"}},
+		{File: filepath.Join(vectorDir, "vector_test.go"), Templates: []string{"vector.test.go.tmpl"}},
+	}
+	if err := bgen.Generate(conf, conf.Package, "./kzg/template/vector/", entries...); err != nil {
+		return err
+	}
+
+	// hiding kzg commitment scheme
+	conf.Package = "hiding"
+	hidingDir := filepath.Join(baseDir, "hiding")
+	entries = []bavard.Entry{
+		{File: filepath.Join(hidingDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(hidingDir, "hiding.go"), Templates: []string{"hiding.go.tmpl"}},
+		{File: filepath.Join(hidingDir, "hiding_test.go"), Templates: []string{"hiding.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./kzg/template/hiding/", entries...)
 
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/field/generator"
 	field "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/accumulator"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
@@ -21,6 +22,7 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/gkr"
 	"github.com/consensys/gnark-crypto/internal/generator/hash_to_field"
 	"github.com/consensys/gnark-crypto/internal/generator/iop"
+	"github.com/consensys/gnark-crypto/internal/generator/ipp"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
 	"github.com/consensys/gnark-crypto/internal/generator/pedersen"
@@ -31,6 +33,7 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/sumcheck"
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils"
 	"github.com/consensys/gnark-crypto/internal/generator/tower"
+	"github.com/consensys/gnark-crypto/internal/generator/transcript"
 )
 
 const (
@@ -76,7 +79,36 @@ func main() {
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
-			if conf.Equal(config.SECP256K1) {
+			if conf.Equal(config.SECP256K1) || conf.Equal(config.SECP256R1) || conf.Equal(config.GRUMPKIN) {
+				return
+			}
+
+			frInfo := config.FieldDependency{
+				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+				FieldPackageName: "fr",
+				ElementType:      "fr.Element",
+				FFTPackagePath:   "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr/fft",
+			}
+
+			fpInfo := config.FieldDependency{
+				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fp",
+				FieldPackageName: "fp",
+				ElementType:      "fp.Element",
+			}
+
+			if conf.Equal(config.PALLAS) || conf.Equal(config.VESTA) {
+				// no pairing: only generate the packages on fr that don't need one
+				assertNoError(fft.Generate(conf, filepath.Join(curveDir, "fr", "fft"), bgen))
+				assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
+				assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
+				assertNoError(sumcheck.Generate(frInfo, filepath.Join(curveDir, "fr", "sumcheck"), bgen))
+				assertNoError(test_vector_utils.Generate(test_vector_utils.Config{
+					FieldDependency:             frInfo,
+					RandomizeMissingHashEntries: false,
+				}, filepath.Join(curveDir, "fr", "test_vector_utils"), bgen))
+				assertNoError(iop.Generate(conf, filepath.Join(curveDir, "fr", "iop"), bgen))
+				assertNoError(hash_to_field.Generate(frInfo, filepath.Join(curveDir, "fr", "hash_to_field"), bgen))
+				assertNoError(hash_to_field.Generate(fpInfo, filepath.Join(curveDir, "fp", "hash_to_field"), bgen))
 				return
 			}
 
@@ -99,6 +131,14 @@ func main() {
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "kzg"), bgen))
 
+			if conf.Equal(config.BN254) || conf.Equal(config.BLS12_381) {
+				// generate inner pairing product arguments
+				assertNoError(ipp.Generate(conf, filepath.Join(curveDir, "ipp"), bgen))
+			}
+
+			// generate pairing-based accumulator
+			assertNoError(accumulator.Generate(conf, filepath.Join(curveDir, "accumulator"), bgen))
+
 			// generate pedersen on fr
 			assertNoError(pedersen.Generate(conf, filepath.Join(curveDir, "fr", "pedersen"), bgen))
 
@@ -111,11 +151,8 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
-			frInfo := config.FieldDependency{
-				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
-				FieldPackageName: "fr",
-				ElementType:      "fr.Element",
-			}
+			// generate typed fiat-shamir transcript
+			assertNoError(transcript.Generate(conf, filepath.Join(curveDir, "transcript"), bgen))
 
 			// generate polynomial on fr
 			assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
@@ -133,6 +170,9 @@ func main() {
 				TestVectorsRelativePath: "../../../../internal/generator/gkr/test_vectors",
 			}, filepath.Join(curveDir, "fr", "gkr"), bgen))
 
+			// generate layered gkr on fr
+			assertNoError(gkr.GenerateLayered(frInfo, filepath.Join(curveDir, "fr", "gkr", "layered"), bgen))
+
 			// generate test vector utils on fr
 			assertNoError(test_vector_utils.Generate(test_vector_utils.Config{
 				FieldDependency:             frInfo,
@@ -142,12 +182,6 @@ func main() {
 			// generate iop functions
 			assertNoError(iop.Generate(conf, filepath.Join(curveDir, "fr", "iop"), bgen))
 
-			fpInfo := config.FieldDependency{
-				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fp",
-				FieldPackageName: "fp",
-				ElementType:      "fp.Element",
-			}
-
 			// generate wrapped hash-to-field for both fr and fp
 			assertNoError(hash_to_field.Generate(frInfo, filepath.Join(curveDir, "fr", "hash_to_field"), bgen))
 			assertNoError(hash_to_field.Generate(fpInfo, filepath.Join(curveDir, "fp", "hash_to_field"), bgen))
@@ -172,6 +206,23 @@ func main() {
 
 	}
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// edwards25519 is not the companion of a pairing-friendly curve;
+		// only its fields are generated, the curve arithmetic lives in ecc/edwards25519.
+		curveDir := filepath.Join(baseDir, "ecc", "edwards25519")
+
+		fp, err := field.NewFieldConfig("fp", "Element", "57896044618658097711785492504343953926634992332820282019728792003956564819949", true)
+		assertNoError(err)
+		fr, err := field.NewFieldConfig("fr", "Element", "7237005577332262213973186563042994240857116359379907606001950938285454250989", true)
+		assertNoError(err)
+
+		assertNoError(generator.GenerateFF(fp, filepath.Join(curveDir, "fp")))
+		assertNoError(generator.GenerateFF(fr, filepath.Join(curveDir, "fr")))
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
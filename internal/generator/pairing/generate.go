@@ -11,8 +11,10 @@ import (
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	packageName := strings.ReplaceAll(conf.Name, "-", "")
-	return bgen.Generate(conf, packageName, "./pairing/template", bavard.Entry{
-		File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"},
-	})
+	return bgen.Generate(conf, packageName, "./pairing/template",
+		bavard.Entry{File: filepath.Join(baseDir, "pairing_test.go"), Templates: []string{"tests/pairing.go.tmpl"}},
+		bavard.Entry{File: filepath.Join(baseDir, "pairing_lines.go"), Templates: []string{"pairing_lines.go.tmpl"}},
+		bavard.Entry{File: filepath.Join(baseDir, "pairing_lines_test.go"), Templates: []string{"tests/pairing_lines.go.tmpl"}},
+	)
 
 }
@@ -16,6 +16,20 @@ func Generate(conf config.FieldDependency, baseDir string, generateTests bool, b
 		{File: filepath.Join(baseDir, "pool.go"), Templates: []string{"pool.go.tmpl"}},
 	}
 
+	// fast arithmetic relies on the FFT
+	if conf.FFTPackagePath != "" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "arithmetic.go"), Templates: []string{"arithmetic.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "multipoint.go"), Templates: []string{"multipoint.go.tmpl"}},
+		)
+		if generateTests {
+			entries = append(entries,
+				bavard.Entry{File: filepath.Join(baseDir, "arithmetic_test.go"), Templates: []string{"arithmetic.test.go.tmpl"}},
+				bavard.Entry{File: filepath.Join(baseDir, "multipoint_test.go"), Templates: []string{"multipoint.test.go.tmpl"}},
+			)
+		}
+	}
+
 	if generateTests {
 		entries = append(entries,
 			bavard.Entry{File: filepath.Join(baseDir, "polynomial_test.go"), Templates: []string{"polynomial.test.go.tmpl"}},
@@ -10,6 +10,14 @@ func Generate(conf config.FieldDependency, baseDir string, bgen *bavard.BatchGen
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "sumcheck.go"), Templates: []string{"sumcheck.go.tmpl"}},
 		{File: filepath.Join(baseDir, "sumcheck_test.go"), Templates: []string{"sumcheck.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "composition.go"), Templates: []string{"composition.go.tmpl"}},
 	}
+
+	// random small rationals quickly outgrow the fixed size encoding used by the transcript
+	if conf.ElementType == "fr.Element" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "composition_test.go"), Templates: []string{"composition.test.go.tmpl"}})
+	}
+
 	return bgen.Generate(conf, "sumcheck", "./sumcheck/template/", entries...)
 }
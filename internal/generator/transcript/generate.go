@@ -0,0 +1,21 @@
+package transcript
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// typed fiat-shamir transcript
+	conf.Package = "transcript"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "transcript.go"), Templates: []string{"transcript.go.tmpl"}},
+		{File: filepath.Join(baseDir, "transcript_test.go"), Templates: []string{"transcript.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./transcript/template/", entries...)
+
+}